@@ -0,0 +1,231 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1
+
+import (
+	"reflect"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// DatasourceAnnotation keeps legacy v1alpha1 spec.datasource, which has no v1 equivalent, so that IBMLicensing
+// converted to v1 and back does not lose it. License Service collects data with datacollector when the annotation
+// is not set.
+const DatasourceAnnotation = "operator.ibm.com/v1alpha1-datasource"
+
+const datacollectorDatasource = "datacollector"
+
+// blank assignment to verify that IBMLicensing implements conversion.Convertible
+var _ conversion.Convertible = &IBMLicensing{}
+
+// ConvertTo converts IBMLicensing v1 to the v1alpha1 hub version
+func (src *IBMLicensing) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.IBMLicensing)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	status := src.Status.DeepCopy()
+	dst.Status = v1alpha1.IBMLicensingStatus{
+		LicensingPods:      status.LicensingPods,
+		Conditions:         status.Conditions,
+		ObservedGeneration: status.ObservedGeneration,
+	}
+	for _, certificate := range status.Certificates {
+		dst.Status.Certificates = append(dst.Status.Certificates, v1alpha1.CertificateStatus(certificate))
+	}
+	for _, tokenRotation := range status.TokenRotation {
+		dst.Status.TokenRotation = append(dst.Status.TokenRotation, v1alpha1.TokenRotationStatus(tokenRotation))
+	}
+
+	spec := src.Spec.DeepCopy()
+	dstSpec := &dst.Spec
+	dstSpec.Container = v1alpha1.Container(spec.Container)
+	dstSpec.EnvVariable = spec.EnvVariable
+	dstSpec.LogLevel = spec.LogLevel
+	dstSpec.ImagePullSecrets = spec.ImagePullSecrets
+	dstSpec.Version = spec.Version
+	dstSpec.InstanceNamespace = spec.InstanceNamespace
+
+	dstSpec.Datasource = datacollectorDatasource
+	if datasource, ok := dst.GetAnnotations()[DatasourceAnnotation]; ok {
+		dstSpec.Datasource = datasource
+		delete(dst.Annotations, DatasourceAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	if exposure := spec.Exposure; exposure != nil {
+		if exposure.Route != nil {
+			dstSpec.RouteEnabled = exposure.Route.Enabled
+			if exposure.Route.TLS != nil {
+				dstSpec.RouteOptions = &v1alpha1.IBMLicenseServiceRouteOptions{TLS: exposure.Route.TLS}
+			}
+		}
+		if ingress := exposure.Ingress; ingress != nil {
+			dstSpec.IngressEnabled = ingress.Enabled
+			if ingress.Path != nil || ingress.Annotations != nil || ingress.TLS != nil || ingress.Host != nil {
+				dstSpec.IngressOptions = &v1alpha1.IBMLicensingIngressOptions{
+					Path:        ingress.Path,
+					Annotations: ingress.Annotations,
+					TLS:         ingress.TLS,
+					Host:        ingress.Host,
+				}
+			}
+		}
+	}
+
+	if security := spec.Security; security != nil {
+		if security.HTTPS != nil {
+			dstSpec.HTTPSEnable = security.HTTPS.Enabled
+			dstSpec.HTTPSCertsSource = v1alpha1.HTTPSCertsSource(security.HTTPS.CertsSource)
+			if issuer := security.HTTPS.CertManagerIssuer; issuer != nil {
+				dstSpec.CertManagerIssuer = (*v1alpha1.CertManagerIssuer)(issuer)
+			}
+		}
+		dstSpec.APISecretToken = security.APISecretToken
+		dstSpec.TokenRotation = (*v1alpha1.IBMLicensingTokenRotation)(security.TokenRotation)
+		if security.RunAsUser != nil {
+			dstSpec.SecurityContext = &v1alpha1.IBMLicensingSecurityContext{RunAsUser: *security.RunAsUser}
+		}
+	}
+
+	if metrics := spec.Metrics; metrics != nil {
+		if metrics.Usage != nil {
+			dstSpec.UsageEnabled = metrics.Usage.Enabled
+			dstSpec.UsageContainer = v1alpha1.Container(metrics.Usage.Container)
+		}
+		if metrics.Chargeback != nil {
+			dstSpec.ChargebackEnabled = metrics.Chargeback.Enabled
+			dstSpec.ChargebackRetentionPeriod = metrics.Chargeback.RetentionPeriod
+		}
+		if metrics.RHMP != nil {
+			dstSpec.RHMPEnabled = metrics.RHMP.Enabled
+		}
+	}
+
+	if spec.Sender != nil {
+		sender := v1alpha1.IBMLicensingSenderSpec(*spec.Sender)
+		dstSpec.Sender = &sender
+	}
+	if spec.Decommission != nil {
+		decommission := v1alpha1.IBMLicensingDecommission(*spec.Decommission)
+		dstSpec.Decommission = &decommission
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version of IBMLicensing to v1
+func (dst *IBMLicensing) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.IBMLicensing)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	status := src.Status.DeepCopy()
+	dst.Status = IBMLicensingStatus{
+		LicensingPods:      status.LicensingPods,
+		Conditions:         status.Conditions,
+		ObservedGeneration: status.ObservedGeneration,
+	}
+	for _, certificate := range status.Certificates {
+		dst.Status.Certificates = append(dst.Status.Certificates, CertificateStatus(certificate))
+	}
+	for _, tokenRotation := range status.TokenRotation {
+		dst.Status.TokenRotation = append(dst.Status.TokenRotation, TokenRotationStatus(tokenRotation))
+	}
+
+	spec := src.Spec.DeepCopy()
+	dstSpec := &dst.Spec
+	dstSpec.Container = Container(spec.Container)
+	dstSpec.EnvVariable = spec.EnvVariable
+	dstSpec.LogLevel = spec.LogLevel
+	dstSpec.ImagePullSecrets = spec.ImagePullSecrets
+	dstSpec.Version = spec.Version
+	dstSpec.InstanceNamespace = spec.InstanceNamespace
+
+	// empty datasource is kept too, so that it is not defaulted when converted back
+	if spec.Datasource != datacollectorDatasource {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[DatasourceAnnotation] = spec.Datasource
+	}
+
+	var exposure IBMLicensingExposure
+	if spec.RouteEnabled != nil || spec.RouteOptions != nil {
+		exposure.Route = &IBMLicensingRoute{Enabled: spec.RouteEnabled}
+		if spec.RouteOptions != nil {
+			exposure.Route.TLS = spec.RouteOptions.TLS
+		}
+	}
+	if spec.IngressEnabled != nil || spec.IngressOptions != nil {
+		exposure.Ingress = &IBMLicensingIngress{Enabled: spec.IngressEnabled}
+		if options := spec.IngressOptions; options != nil {
+			exposure.Ingress.Path = options.Path
+			exposure.Ingress.Annotations = options.Annotations
+			exposure.Ingress.TLS = options.TLS
+			exposure.Ingress.Host = options.Host
+		}
+	}
+	if exposure.Route != nil || exposure.Ingress != nil {
+		dstSpec.Exposure = &exposure
+	}
+
+	var security IBMLicensingSecurity
+	if spec.HTTPSEnable || spec.HTTPSCertsSource != "" || spec.CertManagerIssuer != nil {
+		security.HTTPS = &IBMLicensingHTTPS{
+			Enabled:           spec.HTTPSEnable,
+			CertsSource:       HTTPSCertsSource(spec.HTTPSCertsSource),
+			CertManagerIssuer: (*CertManagerIssuer)(spec.CertManagerIssuer),
+		}
+	}
+	security.APISecretToken = spec.APISecretToken
+	security.TokenRotation = (*IBMLicensingTokenRotation)(spec.TokenRotation)
+	if spec.SecurityContext != nil {
+		security.RunAsUser = &spec.SecurityContext.RunAsUser
+	}
+	if security != (IBMLicensingSecurity{}) {
+		dstSpec.Security = &security
+	}
+
+	var metrics IBMLicensingMetrics
+	if spec.UsageEnabled || !reflect.DeepEqual(spec.UsageContainer, v1alpha1.Container{}) {
+		metrics.Usage = &IBMLicensingUsage{
+			Enabled:   spec.UsageEnabled,
+			Container: Container(spec.UsageContainer),
+		}
+	}
+	if spec.ChargebackEnabled != nil || spec.ChargebackRetentionPeriod != nil {
+		metrics.Chargeback = &IBMLicensingChargeback{
+			Enabled:         spec.ChargebackEnabled,
+			RetentionPeriod: spec.ChargebackRetentionPeriod,
+		}
+	}
+	if spec.RHMPEnabled != nil {
+		metrics.RHMP = &IBMLicensingRHMP{Enabled: spec.RHMPEnabled}
+	}
+	if metrics != (IBMLicensingMetrics{}) {
+		dstSpec.Metrics = &metrics
+	}
+
+	if spec.Sender != nil {
+		sender := IBMLicensingSender(*spec.Sender)
+		dstSpec.Sender = &sender
+	}
+	if spec.Decommission != nil {
+		decommission := IBMLicensingDecommission(*spec.Decommission)
+		dstSpec.Decommission = &decommission
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+var (
+	enabled          = true
+	disabled         = false
+	retentionPeriod  = 62
+	runAsUser        = int64(1000)
+	ingressPath      = "/license-service"
+	ingressHost      = "licensing.example.com"
+	conversionTime   = metav1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	conversionExpiry = metav1.NewTime(time.Date(2021, 6, 2, 12, 0, 0, 0, time.UTC))
+)
+
+func TestConvertV1Alpha1RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance *v1alpha1.IBMLicensing
+	}{
+		{
+			name: "minimal",
+			instance: &v1alpha1.IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance"},
+				Spec:       v1alpha1.IBMLicensingSpec{Datasource: datacollectorDatasource},
+			},
+		},
+		{
+			name:     "empty datasource",
+			instance: &v1alpha1.IBMLicensing{ObjectMeta: metav1.ObjectMeta{Name: "instance"}},
+		},
+		{
+			name: "metering datasource",
+			instance: &v1alpha1.IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Annotations: map[string]string{"owner": "team"}},
+				Spec: v1alpha1.IBMLicensingSpec{
+					Datasource:  "metering",
+					HTTPSEnable: true,
+				},
+			},
+		},
+		{
+			name: "all fields",
+			instance: &v1alpha1.IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "instance",
+					Labels:      map[string]string{"app": "licensing"},
+					Annotations: map[string]string{"owner": "team"},
+					Generation:  3,
+				},
+				Spec: v1alpha1.IBMLicensingSpec{
+					EnvVariable: map[string]string{"ENV": "value"},
+					Container:   v1alpha1Container("licensing"),
+					IBMLicenseServiceBaseSpec: v1alpha1.IBMLicenseServiceBaseSpec{
+						LogLevel:          "DEBUG",
+						APISecretToken:    "api-token",
+						ImagePullSecrets:  []string{"pull-secret"},
+						HTTPSCertsSource:  v1alpha1.CertManagerCertsSource,
+						CertManagerIssuer: &v1alpha1.CertManagerIssuer{Name: "issuer", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+						RouteOptions:      &v1alpha1.IBMLicenseServiceRouteOptions{TLS: &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}},
+						Version:           "1.7.0",
+					},
+					Datasource:                datacollectorDatasource,
+					HTTPSEnable:               true,
+					InstanceNamespace:         "ibm-common-services",
+					SecurityContext:           &v1alpha1.IBMLicensingSecurityContext{RunAsUser: runAsUser},
+					RouteEnabled:              &enabled,
+					RHMPEnabled:               &disabled,
+					UsageEnabled:              true,
+					UsageContainer:            v1alpha1Container("usage"),
+					ChargebackEnabled:         &enabled,
+					ChargebackRetentionPeriod: &retentionPeriod,
+					IngressEnabled:            &disabled,
+					IngressOptions: &v1alpha1.IBMLicensingIngressOptions{
+						Path:        &ingressPath,
+						Annotations: map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/$2"},
+						TLS:         []networkingv1.IngressTLS{{Hosts: []string{ingressHost}, SecretName: "ingress-tls"}},
+						Host:        &ingressHost,
+					},
+					Sender: &v1alpha1.IBMLicensingSenderSpec{
+						ReporterURL:         "https://reporter",
+						ReporterSecretToken: "reporter-token",
+						ClusterName:         "cluster",
+						ClusterID:           "cluster-id",
+					},
+					Decommission:  &v1alpha1.IBMLicensingDecommission{SnapshotOnDelete: true, SigningKeySecret: "signing-key"},
+					TokenRotation: &v1alpha1.IBMLicensingTokenRotation{Interval: metav1.Duration{Duration: 24 * time.Hour}},
+				},
+				Status: v1alpha1.IBMLicensingStatus{
+					LicensingPods:      []corev1.PodStatus{{Phase: corev1.PodRunning}},
+					Conditions:         []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, Reason: "Available", LastTransitionTime: conversionTime}},
+					ObservedGeneration: 3,
+					Certificates:       []v1alpha1.CertificateStatus{{Name: "cert", NotAfter: conversionExpiry, RenewalTime: conversionTime}},
+					TokenRotation: []v1alpha1.TokenRotationStatus{{
+						SecretName:          "token",
+						LastRotationTime:    conversionTime,
+						NextRotationTime:    conversionExpiry,
+						PreviousTokenExpiry: &conversionExpiry,
+					}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			converted := &IBMLicensing{}
+			if err := converted.ConvertFrom(test.instance.DeepCopy()); err != nil {
+				t.Fatalf("failed to convert from v1alpha1: %v", err)
+			}
+			restored := &v1alpha1.IBMLicensing{}
+			if err := converted.ConvertTo(restored); err != nil {
+				t.Fatalf("failed to convert to v1alpha1: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.instance, restored) {
+				t.Errorf("v1alpha1 changed after round trip: %s", diff.ObjectReflectDiff(test.instance, restored))
+			}
+		})
+	}
+}
+
+func TestConvertV1RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance *IBMLicensing
+	}{
+		{
+			name:     "minimal",
+			instance: &IBMLicensing{ObjectMeta: metav1.ObjectMeta{Name: "instance"}},
+		},
+		{
+			name: "legacy datasource annotation",
+			instance: &IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Annotations: map[string]string{DatasourceAnnotation: "metering"}},
+			},
+		},
+		{
+			name: "all fields",
+			instance: &IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "instance",
+					Labels:      map[string]string{"app": "licensing"},
+					Annotations: map[string]string{"owner": "team"},
+					Generation:  2,
+				},
+				Spec: IBMLicensingSpec{
+					Container:         v1Container("licensing"),
+					EnvVariable:       map[string]string{"ENV": "value"},
+					LogLevel:          "DEBUG",
+					ImagePullSecrets:  []string{"pull-secret"},
+					Version:           "1.7.0",
+					InstanceNamespace: "ibm-common-services",
+					Exposure: &IBMLicensingExposure{
+						Route: &IBMLicensingRoute{
+							Enabled: &enabled,
+							TLS:     &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt},
+						},
+						Ingress: &IBMLicensingIngress{
+							Enabled:     &disabled,
+							Path:        &ingressPath,
+							Annotations: map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/$2"},
+							TLS:         []networkingv1.IngressTLS{{Hosts: []string{ingressHost}, SecretName: "ingress-tls"}},
+							Host:        &ingressHost,
+						},
+					},
+					Security: &IBMLicensingSecurity{
+						HTTPS: &IBMLicensingHTTPS{
+							Enabled:           true,
+							CertsSource:       HTTPSCertsSource(v1alpha1.OperatorCACertsSource),
+							CertManagerIssuer: &CertManagerIssuer{Name: "issuer"},
+						},
+						APISecretToken: "api-token",
+						RunAsUser:      &runAsUser,
+						TokenRotation: &IBMLicensingTokenRotation{
+							Interval:    metav1.Duration{Duration: 24 * time.Hour},
+							GracePeriod: &metav1.Duration{Duration: time.Hour},
+						},
+					},
+					Metrics: &IBMLicensingMetrics{
+						Usage:      &IBMLicensingUsage{Enabled: true, Container: v1Container("usage")},
+						Chargeback: &IBMLicensingChargeback{Enabled: &disabled, RetentionPeriod: &retentionPeriod},
+						RHMP:       &IBMLicensingRHMP{Enabled: &enabled},
+					},
+					Sender:       &IBMLicensingSender{ReporterURL: "https://reporter", ReporterSecretToken: "reporter-token"},
+					Decommission: &IBMLicensingDecommission{SnapshotOnDelete: true},
+				},
+				Status: IBMLicensingStatus{
+					LicensingPods:      []corev1.PodStatus{{Phase: corev1.PodPending}},
+					Conditions:         []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "Reconciling", LastTransitionTime: conversionTime}},
+					ObservedGeneration: 2,
+					Certificates:       []CertificateStatus{{Name: "cert", NotAfter: conversionExpiry, RenewalTime: conversionTime}},
+					TokenRotation:      []TokenRotationStatus{{SecretName: "token", LastRotationTime: conversionTime, NextRotationTime: conversionExpiry}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := &v1alpha1.IBMLicensing{}
+			if err := test.instance.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("failed to convert to v1alpha1: %v", err)
+			}
+			restored := &IBMLicensing{}
+			if err := restored.ConvertFrom(hub); err != nil {
+				t.Fatalf("failed to convert from v1alpha1: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.instance, restored) {
+				t.Errorf("v1 changed after round trip: %s", diff.ObjectReflectDiff(test.instance, restored))
+			}
+		})
+	}
+}
+
+func v1alpha1Container(imageName string) v1alpha1.Container {
+	return v1alpha1.Container{
+		ImageRegistry:   "quay.io/opencloudio",
+		ImageName:       imageName,
+		ImageTagPostfix: "-amd64",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+	}
+}
+
+func v1Container(imageName string) Container {
+	return Container(v1alpha1Container(imageName))
+}
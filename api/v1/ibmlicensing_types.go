@@ -0,0 +1,375 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1
+
+import (
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// Container defines image and resources of application container
+type Container struct {
+	// IBM Licensing Service docker Image Registry, will override default value and disable IBM_LICENSING_IMAGE env value in operator deployment
+	// +optional
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	// IBM Licensing Service docker Image Name, will override default value and disable IBM_LICENSING_IMAGE env value in operator deployment
+	// +optional
+	ImageName string `json:"imageName,omitempty"`
+	// IBM Licensing Service docker Image Tag or Digest, will override default value and disable IBM_LICENSING_IMAGE env value in operator deployment
+	// +optional
+	ImageTagPostfix string `json:"imageTagPostfix,omitempty"`
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+}
+
+// HTTPSCertsSource describes how certificate is set in available APIs
+type HTTPSCertsSource string
+
+const (
+	// OcpCertsSource means application will use cert manager
+	OcpCertsSource HTTPSCertsSource = "ocp"
+	// SelfSignedCertsSource means application will create certificate by itself and use it
+	SelfSignedCertsSource HTTPSCertsSource = "self-signed"
+	// CustomCertsSource means application will use certificate created by user
+	CustomCertsSource HTTPSCertsSource = "custom"
+	// CertManagerCertsSource means application will use certificate issued by cert-manager
+	CertManagerCertsSource HTTPSCertsSource = "cert-manager"
+	// OperatorCACertsSource means application will use certificate issued by CA maintained by the operator
+	OperatorCACertsSource HTTPSCertsSource = "operator-ca"
+)
+
+// CertificateStatus describes certificate issued by CA maintained by the operator
+type CertificateStatus struct {
+	// Name of the secret with the certificate
+	Name string `json:"name"`
+	// Time when the certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+	// Time after which the operator renews the certificate
+	RenewalTime metav1.Time `json:"renewalTime"`
+}
+
+// CertManagerIssuer references cert-manager issuer of certificates
+type CertManagerIssuer struct {
+	// Name of the issuer
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer from the namespace of certificates or ClusterIssuer, defaults to Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// API group of the issuer, defaults to cert-manager.io, set it for external issuers
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// IBMLicensingSpec defines the desired state of IBMLicensing
+type IBMLicensingSpec struct {
+
+	// Container Settings
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container Settings",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	Container `json:",inline"`
+
+	// Environment variable setting
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment variable setting",xDescriptors="urn:alm:descriptor:com.tectonic.ui:hidden"
+	// +optional
+	EnvVariable map[string]string `json:"envVariable,omitempty"`
+
+	// Should application pod show additional information, options: DEBUG, INFO, VERBOSE
+	// +kubebuilder:validation:Enum=DEBUG;INFO;VERBOSE
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Array of pull secrets which should include existing at InstanceNamespace secret to allow pulling IBM Licensing image
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Version
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Existing or to be created namespace where application will start
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Instance Namespace",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	InstanceNamespace string `json:"instanceNamespace,omitempty"`
+
+	// How IBM Licensing Service API is exposed outside of the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exposure",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	Exposure *IBMLicensingExposure `json:"exposure,omitempty"`
+
+	// HTTPS, API token and pod security settings
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Security",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	Security *IBMLicensingSecurity `json:"security,omitempty"`
+
+	// Additional metrics collected and exposed by IBM Licensing Service
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	Metrics *IBMLicensingMetrics `json:"metrics,omitempty"`
+
+	// Sender configuration, set if you have multi-cluster environment from which you collect data
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sender",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	// +optional
+	Sender *IBMLicensingSender `json:"sender,omitempty"`
+
+	// Actions performed by the operator before the instance is deleted
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Decommission",xDescriptors="urn:alm:descriptor:com.tectonic.ui:hidden"
+	// +optional
+	Decommission *IBMLicensingDecommission `json:"decommission,omitempty"`
+}
+
+// IBMLicensingDecommission configures cleanup done by the operator when IBMLicensing is deleted
+type IBMLicensingDecommission struct {
+	// Retrieve an audit snapshot from License Service and store it in a secret, which is not deleted with the instance,
+	// before the instance is deleted
+	// +optional
+	SnapshotOnDelete bool `json:"snapshotOnDelete,omitempty"`
+	// Name of secret in License Service namespace with ed25519 or RSA private key in PEM format under privateKey key,
+	// when set the audit snapshot is stored with SHA-256 manifest and its detached signature
+	// +optional
+	SigningKeySecret string `json:"signingKeySecret,omitempty"`
+}
+
+// IBMLicensingTokenRotation configures periodic rotation of API and upload tokens created by the operator
+type IBMLicensingTokenRotation struct {
+	// Time after which a new token is generated, f.e. 720h
+	Interval metav1.Duration `json:"interval"`
+	// Time during which the previous token stays valid after rotation, defaults to 24h
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// TokenRotationStatus describes rotation of token stored in a secret
+type TokenRotationStatus struct {
+	// Name of the secret with the token
+	SecretName string `json:"secretName"`
+	// Time when the current token was generated
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+	// Time after which the operator generates a new token
+	NextRotationTime metav1.Time `json:"nextRotationTime"`
+	// Time when the previous token is removed from the secret, set during the grace period
+	// +optional
+	PreviousTokenExpiry *metav1.Time `json:"previousTokenExpiry,omitempty"`
+}
+
+// IBMLicensingExposure defines Route and Ingress exposing IBM Licensing Service API
+type IBMLicensingExposure struct {
+	// Route exposing IBM Licensing Service API, only on OpenShift cluster
+	// +optional
+	Route *IBMLicensingRoute `json:"route,omitempty"`
+
+	// Ingress exposing IBM Licensing Service API
+	// +optional
+	Ingress *IBMLicensingIngress `json:"ingress,omitempty"`
+}
+
+// IBMLicensingRoute defines Route exposing IBM Licensing Service API
+type IBMLicensingRoute struct {
+	// Should Route be created, by default it is created when Route API is available
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TLS Config
+	// +optional
+	TLS *routev1.TLSConfig `json:"tls,omitempty"`
+}
+
+// IBMLicensingIngress defines Ingress exposing IBM Licensing Service API
+type IBMLicensingIngress struct {
+	// Should Ingress be created, by default it is created when Route API is not available
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Path after host where API will be available f.e. https://<hostname>:<port>/ibm-licensing-service-instance
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Additional annotations that should include f.e. ingress class if using not default ingress controller
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TLS Options to enable secure connection
+	// +optional
+	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
+
+	// If you use non-default host include it here
+	// +optional
+	Host *string `json:"host,omitempty"`
+}
+
+// IBMLicensingSecurity defines HTTPS, API token and pod security settings
+type IBMLicensingSecurity struct {
+	// HTTPS access at pod level
+	// +optional
+	HTTPS *IBMLicensingHTTPS `json:"https,omitempty"`
+
+	// Secret name used to store application token, either one that exists, or one that will be created
+	// +optional
+	APISecretToken string `json:"apiSecretToken,omitempty"`
+
+	// If default SCC user ID fails, you can set runAsUser option to fix that
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// Periodic rotation of API and upload tokens, tokens are not rotated when not set
+	// +optional
+	TokenRotation *IBMLicensingTokenRotation `json:"tokenRotation,omitempty"`
+}
+
+// IBMLicensingHTTPS defines HTTPS access at pod level
+type IBMLicensingHTTPS struct {
+	// Enables https access at pod level, certsSource needed if true
+	Enabled bool `json:"enabled"`
+
+	// options: self-signed, custom, ocp, cert-manager or operator-ca
+	// +kubebuilder:validation:Enum=self-signed;custom;ocp;cert-manager;operator-ca
+	// +optional
+	CertsSource HTTPSCertsSource `json:"certsSource,omitempty"`
+
+	// Issuer of certificates, required when certsSource is cert-manager
+	// +optional
+	CertManagerIssuer *CertManagerIssuer `json:"certManagerIssuer,omitempty"`
+}
+
+// IBMLicensingMetrics defines additional metrics collected and exposed by IBM Licensing Service
+type IBMLicensingMetrics struct {
+	// Usage based metrics
+	// +optional
+	Usage *IBMLicensingUsage `json:"usage,omitempty"`
+
+	// Chargeback metrics
+	// +optional
+	Chargeback *IBMLicensingChargeback `json:"chargeback,omitempty"`
+
+	// Red Hat Marketplace metrics
+	// +optional
+	RHMP *IBMLicensingRHMP `json:"rhmp,omitempty"`
+}
+
+// IBMLicensingUsage defines collection of usage based metrics
+type IBMLicensingUsage struct {
+	// Should collect usage based metrics?
+	Enabled bool `json:"enabled"`
+
+	// Usage Container Settings
+	// +optional
+	Container Container `json:"container,omitempty"`
+}
+
+// IBMLicensingChargeback defines collection of chargeback metrics
+type IBMLicensingChargeback struct {
+	// Consider updating to enable chargeback feature
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Chargeback data retention period in days. Default value is 62 days.
+	// +optional
+	RetentionPeriod *int `json:"retentionPeriod,omitempty"`
+}
+
+// IBMLicensingRHMP defines metrics exposed for Red Hat Marketplace
+type IBMLicensingRHMP struct {
+	// Is Red Hat Marketplace enabled, by default it is enabled on OpenShift cluster
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IBMLicensingSender defines sending data to License Service Reporter
+type IBMLicensingSender struct {
+
+	// URL for License Service Reporter receiver that collects and aggregate multi cluster licensing data.
+	// +optional
+	ReporterURL string `json:"reporterURL,omitempty"`
+
+	// License Service Reporter authentication token, provided by secret that you need to create in instance namespace
+	// +optional
+	ReporterSecretToken string `json:"reporterSecretToken,omitempty"`
+
+	// What is the name of this reporting cluster in multi-cluster system. If not provided, CLUSTER_ID will be used as CLUSTER_NAME at Operand level
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Unique ID of reporting cluster
+	// +optional
+	ClusterID string `json:"clusterID,omitempty"`
+}
+
+// IBMLicensingStatus defines the observed state of IBMLicensing
+type IBMLicensingStatus struct {
+	// The status of IBM License Service Pods.
+	LicensingPods []corev1.PodStatus `json:"licensingPods"`
+
+	// Conditions of IBM License Service: Ready, Progressing and Degraded
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Generation of IBMLicensing which was reconciled the last time
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Certificates issued by CA maintained by the operator, when httpsCertsSource is operator-ca
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// Rotation of tokens created by the operator, when tokenRotation is set
+	// +optional
+	TokenRotation []TokenRotationStatus `json:"tokenRotation,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IBM License Service is the Schema for the ibmlicensings API.
+// Documentation For additional details regarding install parameters check: https://ibm.biz/icpfs39install.
+// License By installing this product you accept the license terms https://ibm.biz/icpfs39license.
+// +kubebuilder:printcolumn:name="Pod Phase",type=string,JSONPath=`.status..phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=ibmlicensings,scope=Cluster
+// +kubebuilder:unservedversion
+// +operator-sdk:csv:customresourcedefinitions:displayName="IBM License Service"
+type IBMLicensing struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBMLicensingSpec   `json:"spec,omitempty"`
+	Status IBMLicensingStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IBMLicensingList contains a list of IBMLicensing
+type IBMLicensingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBMLicensing `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBMLicensing{}, &IBMLicensingList{})
+}
@@ -0,0 +1,554 @@
+// +build !ignore_autogenerated
+
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuer) DeepCopyInto(out *CertManagerIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuer.
+func (in *CertManagerIssuer) DeepCopy() *CertManagerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	in.RenewalTime.DeepCopyInto(&out.RenewalTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Container) DeepCopyInto(out *Container) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Container.
+func (in *Container) DeepCopy() *Container {
+	if in == nil {
+		return nil
+	}
+	out := new(Container)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensing) DeepCopyInto(out *IBMLicensing) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensing.
+func (in *IBMLicensing) DeepCopy() *IBMLicensing {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensing) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingChargeback) DeepCopyInto(out *IBMLicensingChargeback) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingChargeback.
+func (in *IBMLicensingChargeback) DeepCopy() *IBMLicensingChargeback {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingChargeback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingDecommission) DeepCopyInto(out *IBMLicensingDecommission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingDecommission.
+func (in *IBMLicensingDecommission) DeepCopy() *IBMLicensingDecommission {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingDecommission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingExposure) DeepCopyInto(out *IBMLicensingExposure) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(IBMLicensingRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IBMLicensingIngress)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingExposure.
+func (in *IBMLicensingExposure) DeepCopy() *IBMLicensingExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingHTTPS) DeepCopyInto(out *IBMLicensingHTTPS) {
+	*out = *in
+	if in.CertManagerIssuer != nil {
+		in, out := &in.CertManagerIssuer, &out.CertManagerIssuer
+		*out = new(CertManagerIssuer)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingHTTPS.
+func (in *IBMLicensingHTTPS) DeepCopy() *IBMLicensingHTTPS {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingHTTPS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingIngress) DeepCopyInto(out *IBMLicensingIngress) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingIngress.
+func (in *IBMLicensingIngress) DeepCopy() *IBMLicensingIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingList) DeepCopyInto(out *IBMLicensingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBMLicensing, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingList.
+func (in *IBMLicensingList) DeepCopy() *IBMLicensingList {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingMetrics) DeepCopyInto(out *IBMLicensingMetrics) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(IBMLicensingUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Chargeback != nil {
+		in, out := &in.Chargeback, &out.Chargeback
+		*out = new(IBMLicensingChargeback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RHMP != nil {
+		in, out := &in.RHMP, &out.RHMP
+		*out = new(IBMLicensingRHMP)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingMetrics.
+func (in *IBMLicensingMetrics) DeepCopy() *IBMLicensingMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingRHMP) DeepCopyInto(out *IBMLicensingRHMP) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingRHMP.
+func (in *IBMLicensingRHMP) DeepCopy() *IBMLicensingRHMP {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingRHMP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingRoute) DeepCopyInto(out *IBMLicensingRoute) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(routev1.TLSConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingRoute.
+func (in *IBMLicensingRoute) DeepCopy() *IBMLicensingRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingSecurity) DeepCopyInto(out *IBMLicensingSecurity) {
+	*out = *in
+	if in.HTTPS != nil {
+		in, out := &in.HTTPS, &out.HTTPS
+		*out = new(IBMLicensingHTTPS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TokenRotation != nil {
+		in, out := &in.TokenRotation, &out.TokenRotation
+		*out = new(IBMLicensingTokenRotation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingSecurity.
+func (in *IBMLicensingSecurity) DeepCopy() *IBMLicensingSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingSender) DeepCopyInto(out *IBMLicensingSender) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingSender.
+func (in *IBMLicensingSender) DeepCopy() *IBMLicensingSender {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingSender)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingSpec) DeepCopyInto(out *IBMLicensingSpec) {
+	*out = *in
+	in.Container.DeepCopyInto(&out.Container)
+	if in.EnvVariable != nil {
+		in, out := &in.EnvVariable, &out.EnvVariable
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(IBMLicensingExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(IBMLicensingSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(IBMLicensingMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sender != nil {
+		in, out := &in.Sender, &out.Sender
+		*out = new(IBMLicensingSender)
+		**out = **in
+	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(IBMLicensingDecommission)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingSpec.
+func (in *IBMLicensingSpec) DeepCopy() *IBMLicensingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingStatus) DeepCopyInto(out *IBMLicensingStatus) {
+	*out = *in
+	if in.LicensingPods != nil {
+		in, out := &in.LicensingPods, &out.LicensingPods
+		*out = make([]corev1.PodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TokenRotation != nil {
+		in, out := &in.TokenRotation, &out.TokenRotation
+		*out = make([]TokenRotationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingStatus.
+func (in *IBMLicensingStatus) DeepCopy() *IBMLicensingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingTokenRotation) DeepCopyInto(out *IBMLicensingTokenRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingTokenRotation.
+func (in *IBMLicensingTokenRotation) DeepCopy() *IBMLicensingTokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingTokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingUsage) DeepCopyInto(out *IBMLicensingUsage) {
+	*out = *in
+	in.Container.DeepCopyInto(&out.Container)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingUsage.
+func (in *IBMLicensingUsage) DeepCopy() *IBMLicensingUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenRotationStatus) DeepCopyInto(out *TokenRotationStatus) {
+	*out = *in
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	in.NextRotationTime.DeepCopyInto(&out.NextRotationTime)
+	if in.PreviousTokenExpiry != nil {
+		in, out := &in.PreviousTokenExpiry, &out.PreviousTokenExpiry
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenRotationStatus.
+func (in *TokenRotationStatus) DeepCopy() *TokenRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
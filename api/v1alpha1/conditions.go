@@ -0,0 +1,108 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types shared by operator custom resources
+const (
+	// ConditionReady is True when all operands are deployed and available
+	ConditionReady = "Ready"
+	// ConditionProgressing is True when operator is still creating or updating operands
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is True when last reconciliation failed
+	ConditionDegraded = "Degraded"
+
+	// ConditionDatabaseReady is True when License Service Reporter database container is ready
+	ConditionDatabaseReady = "DatabaseReady"
+	// ConditionReceiverReady is True when License Service Reporter receiver container is ready
+	ConditionReceiverReady = "ReceiverReady"
+	// ConditionUIEnabled is True when License Service Reporter is deployed with UI container
+	ConditionUIEnabled = "UIEnabled"
+	// ConditionStorageBound is True when License Service Reporter persistent volume claim is bound
+	ConditionStorageBound = "StorageBound"
+
+	// ConditionValid is True when IBMLicensingMetadata annotations conform to licensing annotations schema
+	ConditionValid = "Valid"
+	// ConditionConflicting is True when other IBMLicensingMetadata matches the same pods with different extend values
+	ConditionConflicting = "Conflicting"
+)
+
+// Condition reasons shared by operator custom resources
+const (
+	ReasonAvailable            = "Available"
+	ReasonInvalidConfiguration = "InvalidConfiguration"
+	ReasonWaitingForDeployment = "WaitingForDeployment"
+	ReasonWaitingForCertSecret = "WaitingForCertSecret"
+
+	ReasonContainerReady         = "ContainerReady"
+	ReasonContainerNotReady      = "ContainerNotReady"
+	ReasonPodNotFound            = "PodNotFound"
+	ReasonOidcCredentialsFound   = "OidcCredentialsFound"
+	ReasonOidcCredentialsMissing = "OidcCredentialsMissing"
+	ReasonUIEnabledInSpec        = "UIEnabledInSpec"
+	ReasonUIDisabledInSpec       = "UIDisabledInSpec"
+	ReasonPVCNotFound            = "PersistentVolumeClaimNotFound"
+
+	ReasonAnnotationsValid      = "AnnotationsValid"
+	ReasonOverlappingMetadata   = "OverlappingMetadata"
+	ReasonNoOverlappingMetadata = "NoOverlappingMetadata"
+
+	ReasonSnapshotsScheduled = "SnapshotsScheduled"
+	ReasonSnapshotsSuspended = "SnapshotsSuspended"
+	ReasonLicensingNotFound  = "LicensingNotFound"
+)
+
+// SetAvailableConditions marks resource as Ready, not Progressing and not Degraded
+func SetAvailableConditions(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	SetCondition(conditions, generation, ConditionReady, metav1.ConditionTrue, reason, message)
+	SetCondition(conditions, generation, ConditionProgressing, metav1.ConditionFalse, reason, message)
+	SetCondition(conditions, generation, ConditionDegraded, metav1.ConditionFalse, reason, message)
+}
+
+// SetProgressingConditions marks resource as not Ready yet, but Progressing without errors
+func SetProgressingConditions(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	SetCondition(conditions, generation, ConditionReady, metav1.ConditionFalse, reason, message)
+	SetCondition(conditions, generation, ConditionProgressing, metav1.ConditionTrue, reason, message)
+	SetCondition(conditions, generation, ConditionDegraded, metav1.ConditionFalse, reason, message)
+}
+
+// SetDegradedConditions marks resource as Degraded, Ready condition is kept when operands are still available
+func SetDegradedConditions(conditions *[]metav1.Condition, generation int64, reason, message string) {
+	if !meta.IsStatusConditionTrue(*conditions, ConditionReady) {
+		SetCondition(conditions, generation, ConditionReady, metav1.ConditionFalse, reason, message)
+	}
+	SetCondition(conditions, generation, ConditionProgressing, metav1.ConditionFalse, reason, message)
+	SetCondition(conditions, generation, ConditionDegraded, metav1.ConditionTrue, reason, message)
+}
+
+// SetCondition sets single condition, LastTransitionTime is changed only when status changes
+func SetCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus,
+	reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	// SetStatusCondition does not refresh observedGeneration of already existing condition
+	meta.FindStatusCondition(*conditions, conditionType).ObservedGeneration = generation
+}
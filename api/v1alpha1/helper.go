@@ -21,13 +21,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	client_reader "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -40,6 +46,13 @@ const OperandReporterDatabaseImageEnvVar = "IBM_POSTGRESQL_IMAGE"
 const OperandReporterUIImageEnvVar = "IBM_LICENSE_SERVICE_REPORTER_UI_IMAGE"
 const OperandReporterReceiverImageEnvVar = "IBM_LICENSE_SERVICE_REPORTER_IMAGE"
 
+// DefaultsPolicyAnnotation selects which default values are persisted by defaulting webhook:
+// track (default) persists only configuration defaults, so images and resources follow operator defaults after upgrade,
+// pin persists also images and resources at creation
+const DefaultsPolicyAnnotation = "operator.ibm.com/defaults"
+const DefaultsPolicyTrack = "track"
+const DefaultsPolicyPin = "pin"
+
 var cpu50m = resource.NewMilliQuantity(50, resource.DecimalSI)
 var cpu100m = resource.NewMilliQuantity(100, resource.DecimalSI)
 var memory64Mi = resource.NewQuantity(64*1024*1024, resource.BinarySI)
@@ -82,8 +95,34 @@ const (
 	SelfSignedCertsSource HTTPSCertsSource = "self-signed"
 	// CustomCertsSource means application will use certificate created by user
 	CustomCertsSource HTTPSCertsSource = "custom"
+	// CertManagerCertsSource means application will use certificate issued by cert-manager
+	CertManagerCertsSource HTTPSCertsSource = "cert-manager"
+	// OperatorCACertsSource means application will use certificate issued by CA maintained by the operator
+	OperatorCACertsSource HTTPSCertsSource = "operator-ca"
 )
 
+// CertificateStatus describes certificate issued by CA maintained by the operator
+type CertificateStatus struct {
+	// Name of the secret with the certificate
+	Name string `json:"name"`
+	// Time when the certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+	// Time after which the operator renews the certificate
+	RenewalTime metav1.Time `json:"renewalTime"`
+}
+
+// CertManagerIssuer references cert-manager issuer of certificates
+type CertManagerIssuer struct {
+	// Name of the issuer
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer from the namespace of certificates or ClusterIssuer, defaults to Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// API group of the issuer, defaults to cert-manager.io, set it for external issuers
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 type IBMLicenseServiceBaseSpec struct {
 	// Should application pod show additional information, options: DEBUG, INFO, VERBOSE
 	// +kubebuilder:validation:Enum=DEBUG;INFO;VERBOSE
@@ -92,15 +131,27 @@ type IBMLicenseServiceBaseSpec struct {
 	APISecretToken string `json:"apiSecretToken,omitempty"`
 	// Array of pull secrets which should include existing at InstanceNamespace secret to allow pulling IBM Licensing image
 	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
-	// options: self-signed or custom
-	// +kubebuilder:validation:Enum=self-signed;custom;ocp
+	// options: self-signed, custom, ocp, cert-manager or operator-ca
+	// +kubebuilder:validation:Enum=self-signed;custom;ocp;cert-manager;operator-ca
 	HTTPSCertsSource HTTPSCertsSource `json:"httpsCertsSource,omitempty"`
+	// Issuer of certificates, required when httpsCertsSource is cert-manager
+	// +optional
+	CertManagerIssuer *CertManagerIssuer `json:"certManagerIssuer,omitempty"`
 	// Route parameters
 	RouteOptions *IBMLicenseServiceRouteOptions `json:"routeOptions,omitempty"`
 	// Version
 	Version string `json:"version,omitempty"`
 }
 
+// GetOperandCertsSource returns certificates source passed to operands, certificates issued by cert-manager or by the
+// operator CA are read by operands from mounted secret in the same way as certificates issued by OpenShift service CA
+func (spec *IBMLicenseServiceBaseSpec) GetOperandCertsSource() HTTPSCertsSource {
+	if spec.HTTPSCertsSource == CertManagerCertsSource || spec.HTTPSCertsSource == OperatorCACertsSource {
+		return OcpCertsSource
+	}
+	return spec.HTTPSCertsSource
+}
+
 func (spec *IBMLicensingSpec) IsMetering() bool {
 	return spec.Datasource == "metering"
 }
@@ -109,6 +160,28 @@ func (spec *IBMLicensingSpec) GetDefaultReporterTokenName() string {
 	return defaultReporterTokenSecretName
 }
 
+// IsSnapshotOnDelete returns true if audit snapshot should be stored before the instance is deleted
+func (spec *IBMLicensingSpec) IsSnapshotOnDelete() bool {
+	return spec.Decommission != nil && spec.Decommission.SnapshotOnDelete
+}
+
+func (spec *IBMLicensingSpec) GetSigningKeySecret() string {
+	if spec.Decommission == nil {
+		return ""
+	}
+	return spec.Decommission.SigningKeySecret
+}
+
+const defaultTokenGracePeriod = 24 * time.Hour
+
+// GetGracePeriod returns time during which the previous token stays valid after rotation
+func (rotation *IBMLicensingTokenRotation) GetGracePeriod() time.Duration {
+	if rotation.GracePeriod == nil {
+		return defaultTokenGracePeriod
+	}
+	return rotation.GracePeriod.Duration
+}
+
 func (spec *IBMLicensingSpec) IsDebug() bool {
 	return spec.LogLevel == "DEBUG"
 }
@@ -188,6 +261,113 @@ func (spec *IBMLicensingSpec) IsChargebackEnabled() bool {
 	return spec.ChargebackEnabled != nil && *spec.ChargebackEnabled
 }
 
+// IsPinningDefaults returns true if defaults of instance should be pinned at creation
+func IsPinningDefaults(instance metav1.Object) bool {
+	return instance.GetAnnotations()[DefaultsPolicyAnnotation] == DefaultsPolicyPin
+}
+
+// FillPersistentDefaultValues fills default values that are persisted by defaulting webhook, images and resources
+// of containers are filled only when pinDefaults is true
+func (spec *IBMLicensingSpec) FillPersistentDefaultValues(pinDefaults bool, isOCP4CertManager bool, isRouteEnabled bool,
+	rhmpEnabled bool, operatorNamespace string) error {
+	defaulted := spec.DeepCopy()
+	if err := defaulted.FillDefaultValues(isOCP4CertManager, isRouteEnabled, rhmpEnabled, operatorNamespace); err != nil {
+		return err
+	}
+	if pinDefaults {
+		*spec = *defaulted
+		return nil
+	}
+	spec.InstanceNamespace = defaulted.InstanceNamespace
+	spec.HTTPSCertsSource = defaulted.HTTPSCertsSource
+	spec.RouteEnabled = defaulted.RouteEnabled
+	spec.IngressEnabled = defaulted.IngressEnabled
+	spec.RHMPEnabled = defaulted.RHMPEnabled
+	spec.APISecretToken = defaulted.APISecretToken
+	return nil
+}
+
+// FillPersistentDefaultValues fills default values that are persisted by defaulting webhook, images and resources
+// of containers are filled only when pinDefaults is true
+func (spec *IBMLicenseServiceReporterSpec) FillPersistentDefaultValues(pinDefaults bool, reqLogger logr.Logger,
+	r client_reader.Reader) error {
+	defaulted := spec.DeepCopy()
+	if err := defaulted.FillDefaultValues(reqLogger, r); err != nil {
+		return err
+	}
+	if pinDefaults {
+		*spec = *defaulted
+		return nil
+	}
+	spec.APISecretToken = defaulted.APISecretToken
+	spec.HTTPSCertsSource = defaulted.HTTPSCertsSource
+	spec.StorageClass = defaulted.StorageClass
+	spec.Capacity = defaulted.Capacity
+	spec.UI = defaulted.UI
+	return nil
+}
+
+// GetUIEnabledMode returns spec.ui.enabled, which defaults to auto
+func (spec *IBMLicenseServiceReporterSpec) GetUIEnabledMode() UIEnabledMode {
+	if spec.UI == nil || spec.UI.Enabled == "" {
+		return UIEnabledAuto
+	}
+	return spec.UI.Enabled
+}
+
+// IsExportOnDelete returns true if aggregated data should be exported before the instance is deleted
+func (spec *IBMLicenseServiceReporterSpec) IsExportOnDelete() bool {
+	return spec.Decommission != nil && spec.Decommission.ExportOnDelete
+}
+
+const defaultDatabasePasswordLength = 32
+
+// DefaultPasswordCharset are characters of generated passwords, when charset is not set
+const DefaultPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// GetDatabasePasswordLength returns length of generated database passwords
+func (spec *IBMLicenseServiceReporterSpec) GetDatabasePasswordLength() int {
+	if spec.DatabasePassword == nil || spec.DatabasePassword.Length == 0 {
+		return defaultDatabasePasswordLength
+	}
+	return spec.DatabasePassword.Length
+}
+
+// GetDatabasePasswordCharset returns characters of generated database passwords
+func (spec *IBMLicenseServiceReporterSpec) GetDatabasePasswordCharset() string {
+	if spec.DatabasePassword == nil || spec.DatabasePassword.Charset == "" {
+		return DefaultPasswordCharset
+	}
+	return spec.DatabasePassword.Charset
+}
+
+// minPasswordCharsetSize is the minimal number of different characters of generated database passwords
+const minPasswordCharsetSize = 10
+
+// ValidatePasswordCharset checks that passwords generated from the charset are printable ASCII strings without space,
+// as passwords are generated byte by byte, and that the charset has enough different characters
+func ValidatePasswordCharset(charset string) error {
+	characters := map[rune]bool{}
+	for _, character := range charset {
+		if character < '!' || character > '~' {
+			return errors.New("spec.databasePassword.charset must contain only printable ASCII characters without space")
+		}
+		characters[character] = true
+	}
+	if len(characters) < minPasswordCharsetSize {
+		return fmt.Errorf("spec.databasePassword.charset must contain at least %d different characters", minPasswordCharsetSize)
+	}
+	return nil
+}
+
+// GetDatabasePasswordRotationInterval returns time after which database password is rotated, zero when it is not rotated
+func (spec *IBMLicenseServiceReporterSpec) GetDatabasePasswordRotationInterval() time.Duration {
+	if spec.DatabasePassword == nil || spec.DatabasePassword.RotationInterval == nil {
+		return 0
+	}
+	return spec.DatabasePassword.RotationInterval.Duration
+}
+
 func (spec *IBMLicenseServiceReporterSpec) FillDefaultValues(reqLogger logr.Logger, r client_reader.Reader) error {
 	if err := spec.DatabaseContainer.setContainer(OperandReporterDatabaseImageEnvVar); err != nil {
 		return err
@@ -230,8 +410,12 @@ func (spec *IBMLicenseServiceReporterSpec) FillDefaultValues(reqLogger logr.Logg
 	if spec.HTTPSCertsSource == "" {
 		spec.HTTPSCertsSource = OcpCertsSource
 	}
+	if spec.UI == nil {
+		spec.UI = &IBMLicenseServiceReporterUI{}
+	}
+	spec.UI.Enabled = spec.GetUIEnabledMode()
 	if spec.StorageClass == "" {
-		storageClass, err := getStorageClass(reqLogger, r)
+		storageClass, err := GetDefaultStorageClass(reqLogger, r)
 		if err != nil {
 			reqLogger.Error(err, "Failed to get StorageCLass for IBM License Service Reporter")
 			return err
@@ -242,11 +426,12 @@ func (spec *IBMLicenseServiceReporterSpec) FillDefaultValues(reqLogger logr.Logg
 
 }
 
-func getStorageClass(reqLogger logr.Logger, r client_reader.Reader) (string, error) {
+// GetDefaultStorageClass returns name of default storage class with dynamic provisioner
+func GetDefaultStorageClass(reqLogger logr.Logger, r client_reader.Reader) (string, error) {
 	var defaultSC []string
 
 	scList := &storagev1.StorageClassList{}
-	reqLogger.Info("getStorageClass")
+	reqLogger.Info("GetDefaultStorageClass")
 	err := r.List(context.TODO(), scList)
 	if err != nil {
 		return "", err
@@ -422,3 +607,327 @@ func (spec *IBMLicensingSpec) RemoveDefaultSenderParameters() bool {
 
 	return false
 }
+
+// Licensing annotations read by License Service from pods
+const (
+	ProductNameAnnotation              = "productName"
+	ProductIDAnnotation                = "productID"
+	ProductVersionAnnotation           = "productVersion"
+	ProductMetricAnnotation            = "productMetric"
+	ProductChargedContainersAnnotation = "productChargedContainers"
+	ProductCloudpakRatioAnnotation     = "productCloudpakRatio"
+	CloudpakNameAnnotation             = "cloudpakName"
+	CloudpakIDAnnotation               = "cloudpakId"
+	CloudpakVersionAnnotation          = "cloudpakVersion"
+	CloudpakMetricAnnotation           = "cloudpakMetric"
+)
+
+const chargedContainersAll = "All"
+
+var licensingAnnotations = map[string]bool{
+	ProductNameAnnotation:              true,
+	ProductIDAnnotation:                true,
+	ProductVersionAnnotation:           true,
+	ProductMetricAnnotation:            true,
+	ProductChargedContainersAnnotation: true,
+	ProductCloudpakRatioAnnotation:     true,
+	CloudpakNameAnnotation:             true,
+	CloudpakIDAnnotation:               true,
+	CloudpakVersionAnnotation:          true,
+	CloudpakMetricAnnotation:           true,
+}
+
+// KnownLicensingMetrics are metrics that License Service counts license usage for
+var KnownLicensingMetrics = map[string]bool{
+	"PROCESSOR_VALUE_UNIT":   true,
+	"VIRTUAL_PROCESSOR_CORE": true,
+	"RESOURCE_VALUE_UNIT":    true,
+	"MANAGED_VIRTUAL_SERVER": true,
+	"VIRTUAL_SERVER":         true,
+	"INSTALL":                true,
+	"AUTHORIZED_USER":        true,
+	"CONCURRENT_USER":        true,
+	"MONTHLY_ACTIVE_USER":    true,
+	"FREE":                   true,
+}
+
+// Validate checks condition and extend annotations against licensing annotations schema and returns found errors
+func (spec *IBMLicensingMetadataSpec) Validate() []string {
+	var validationErrors []string
+	if len(spec.Condition.Annotation) == 0 && (spec.Condition.PodSelector == nil ||
+		(len(spec.Condition.PodSelector.MatchLabels) == 0 && len(spec.Condition.PodSelector.MatchExpressions) == 0)) {
+		validationErrors = append(validationErrors,
+			"condition.annotation or condition.podSelector must not be empty, otherwise all pods would be matched")
+	}
+	if _, err := spec.Condition.GetPodSelector(); err != nil {
+		validationErrors = append(validationErrors, "condition.podSelector: "+err.Error())
+	}
+	if _, err := spec.Condition.GetNamespaceSelector(); err != nil {
+		validationErrors = append(validationErrors, "condition.namespaceSelector: "+err.Error())
+	}
+	if len(spec.Extend) == 0 {
+		validationErrors = append(validationErrors, "extend must not be empty")
+	}
+
+	keys := make([]string, 0, len(spec.Extend))
+	for key := range spec.Extend {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLicensingAnnotation(key, spec.Extend[key]); err != nil {
+			validationErrors = append(validationErrors, "extend."+key+": "+err.Error())
+		}
+	}
+	return validationErrors
+}
+
+func validateLicensingAnnotation(key, value string) error {
+	if !licensingAnnotations[key] {
+		return errors.New("unknown licensing annotation")
+	}
+	if strings.TrimSpace(value) == "" {
+		return errors.New("value must not be empty")
+	}
+	switch key {
+	case ProductMetricAnnotation, CloudpakMetricAnnotation:
+		if !KnownLicensingMetrics[value] {
+			return fmt.Errorf("unknown metric %q", value)
+		}
+	case ProductChargedContainersAnnotation:
+		if value == chargedContainersAll {
+			return nil
+		}
+		for _, containerName := range strings.Split(value, "|") {
+			if msgs := validation.IsDNS1123Label(containerName); len(msgs) > 0 {
+				return fmt.Errorf("value must be %q or container names separated with \"|\", container name %q is invalid: %s",
+					chargedContainersAll, containerName, strings.Join(msgs, ", "))
+			}
+		}
+	case ProductCloudpakRatioAnnotation:
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("ratio %q must be in format N:M", value)
+		}
+		for _, part := range parts {
+			if number, err := strconv.Atoi(part); err != nil || number <= 0 {
+				return fmt.Errorf("ratio %q must consist of positive integers", value)
+			}
+		}
+	}
+	return nil
+}
+
+// HasPrecedenceOver returns true if extend values of metadata are used instead of values of other metadata matching
+// the same pod, higher priority wins and when priorities are equal, metadata first in namespace and name order wins
+func (metadata *IBMLicensingMetadata) HasPrecedenceOver(other *IBMLicensingMetadata) bool {
+	if metadata.Spec.Priority != other.Spec.Priority {
+		return metadata.Spec.Priority > other.Spec.Priority
+	}
+	if metadata.GetNamespace() != other.GetNamespace() {
+		return metadata.GetNamespace() < other.GetNamespace()
+	}
+	return metadata.GetName() < other.GetName()
+}
+
+// ConflictingKeys returns sorted extend keys that both metadata set to different values
+func (metadata *IBMLicensingMetadata) ConflictingKeys(other *IBMLicensingMetadata) []string {
+	var keys []string
+	for key, value := range metadata.Spec.Extend {
+		if otherValue, ok := other.Spec.Extend[key]; ok && otherValue != value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var requiredLicensingAnnotations = []string{ProductNameAnnotation, ProductIDAnnotation, ProductMetricAnnotation}
+
+// HasLicensingAnnotations returns true if any of productName, productID and productMetric annotations is set
+func HasLicensingAnnotations(annotations map[string]string) bool {
+	for _, key := range requiredLicensingAnnotations {
+		if _, ok := annotations[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePodLicensingAnnotations checks licensing annotations of pod and returns found problems, pods without
+// productName, productID and productMetric annotations are not licensed and are not checked
+func ValidatePodLicensingAnnotations(annotations map[string]string, containerNames []string) []string {
+	if !HasLicensingAnnotations(annotations) {
+		return nil
+	}
+
+	var problems []string
+	for _, key := range requiredLicensingAnnotations {
+		if _, ok := annotations[key]; !ok {
+			problems = append(problems, "missing "+key+" annotation")
+		}
+	}
+	keys := make([]string, 0, len(licensingAnnotations))
+	for key := range licensingAnnotations {
+		if _, ok := annotations[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLicensingAnnotation(key, annotations[key]); err != nil {
+			problems = append(problems, key+": "+err.Error())
+		}
+	}
+
+	if chargedContainers, ok := annotations[ProductChargedContainersAnnotation]; ok && chargedContainers != chargedContainersAll {
+		podContainers := map[string]bool{}
+		for _, containerName := range containerNames {
+			podContainers[containerName] = true
+		}
+		for _, containerName := range strings.Split(chargedContainers, "|") {
+			if containerName != "" && !podContainers[containerName] {
+				problems = append(problems, ProductChargedContainersAnnotation+": container "+containerName+" does not exist in pod")
+			}
+		}
+	}
+	return problems
+}
+
+// GetConflictPolicy returns conflict policy of pod mutating webhook, Keep is used by default
+func (spec *IBMLicensingMetadataSpec) GetConflictPolicy() ConflictPolicy {
+	if spec.ConflictPolicy == "" {
+		return ConflictPolicyKeep
+	}
+	return spec.ConflictPolicy
+}
+
+// GetPodSelector returns selector of pod labels, selector matching everything is returned when podSelector is not set
+func (condition *IBMLicensingMetadataCondition) GetPodSelector() (labels.Selector, error) {
+	if condition.PodSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(condition.PodSelector)
+}
+
+// GetNamespaceSelector returns selector of namespace labels, nil is returned when namespaceSelector is not set,
+// which means that only namespace of IBMLicensingMetadata is matched
+func (condition *IBMLicensingMetadataCondition) GetNamespaceSelector() (labels.Selector, error) {
+	if condition.NamespaceSelector == nil {
+		return nil, nil
+	}
+	return metav1.LabelSelectorAsSelector(condition.NamespaceSelector)
+}
+
+// IsNamespaceMatching returns true if pods from given namespace can be matched by IBMLicensingMetadata
+// created in metadataNamespace
+func (condition *IBMLicensingMetadataCondition) IsNamespaceMatching(metadataNamespace string, namespace metav1.Object) (bool, error) {
+	namespaceSelector, err := condition.GetNamespaceSelector()
+	if err != nil {
+		return false, err
+	}
+	if namespaceSelector == nil {
+		return namespace.GetName() == metadataNamespace, nil
+	}
+	return namespaceSelector.Matches(labels.Set(namespace.GetLabels())), nil
+}
+
+// IsMatching returns true if pod labels match podSelector and pod annotations contain all annotations from condition,
+// namespace of the pod should be checked with IsNamespaceMatching
+func (condition *IBMLicensingMetadataCondition) IsMatching(pod metav1.Object) (bool, error) {
+	podSelector, err := condition.GetPodSelector()
+	if err != nil {
+		return false, err
+	}
+	if !podSelector.Matches(labels.Set(pod.GetLabels())) {
+		return false, nil
+	}
+	podAnnotations := pod.GetAnnotations()
+	for key, value := range condition.Annotation {
+		if podValue, ok := podAnnotations[key]; !ok || podValue != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+const defaultAuditSnapshotLicensing = "instance"
+const defaultAuditSnapshotRetention = 12
+const defaultAuditSnapshotS3Region = "us-east-1"
+
+// auditSnapshotDateLayout is format of days accepted by License Service snapshot API
+const auditSnapshotDateLayout = "2006-01-02"
+
+// GetLicensingName returns name of IBMLicensing instance from which audit snapshots are retrieved
+func (spec *IBMLicensingAuditSnapshotSpec) GetLicensingName() string {
+	if spec.Licensing == "" {
+		return defaultAuditSnapshotLicensing
+	}
+	return spec.Licensing
+}
+
+// GetRetention returns number of audit snapshot archives kept in the storage
+func (spec *IBMLicensingAuditSnapshotSpec) GetRetention() int32 {
+	if spec.Retention == nil {
+		return defaultAuditSnapshotRetention
+	}
+	return *spec.Retention
+}
+
+// GetRegion returns region of the bucket
+func (s3 *IBMLicensingAuditSnapshotS3) GetRegion() string {
+	if s3.Region == "" {
+		return defaultAuditSnapshotS3Region
+	}
+	return s3.Region
+}
+
+// GetDates returns first and last day covered by audit snapshot run at given time, empty when License Service default
+// range should be used
+func (spec *IBMLicensingAuditSnapshotSpec) GetDates(runTime time.Time) (string, string) {
+	if spec.DateRange == nil {
+		return "", ""
+	}
+	if spec.DateRange.LastDays != nil {
+		end := runTime.UTC()
+		return end.AddDate(0, 0, -int(*spec.DateRange.LastDays)).Format(auditSnapshotDateLayout), end.Format(auditSnapshotDateLayout)
+	}
+	return spec.DateRange.Start, spec.DateRange.End
+}
+
+// ValidateStorageAndDates checks fields of IBMLicensingAuditSnapshot which can not be validated by CRD schema
+func (spec *IBMLicensingAuditSnapshotSpec) ValidateStorageAndDates() error {
+	if (spec.Storage.PVC == nil) == (spec.Storage.S3 == nil) {
+		return errors.New("exactly one of storage.pvc and storage.s3 must be set")
+	}
+	if pvc := spec.Storage.PVC; pvc != nil {
+		for _, segment := range strings.Split(pvc.Path, "/") {
+			if segment == ".." {
+				return errors.New("storage.pvc.path must not contain .. segments")
+			}
+		}
+	}
+	dateRange := spec.DateRange
+	if dateRange == nil {
+		return nil
+	}
+	if dateRange.LastDays != nil && (dateRange.Start != "" || dateRange.End != "") {
+		return errors.New("dateRange.lastDays can not be set together with dateRange.start and dateRange.end")
+	}
+	var start, end time.Time
+	var err error
+	if dateRange.Start != "" {
+		if start, err = time.Parse(auditSnapshotDateLayout, dateRange.Start); err != nil {
+			return fmt.Errorf("dateRange.start is not a valid date: %w", err)
+		}
+	}
+	if dateRange.End != "" {
+		if end, err = time.Parse(auditSnapshotDateLayout, dateRange.End); err != nil {
+			return fmt.Errorf("dateRange.end is not a valid date: %w", err)
+		}
+	}
+	if dateRange.Start != "" && dateRange.End != "" && end.Before(start) {
+		return errors.New("dateRange.end must not be before dateRange.start")
+	}
+	return nil
+}
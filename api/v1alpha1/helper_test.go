@@ -0,0 +1,64 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestValidateStorageAndDatesRejectsPVCPathOutsideVolume(t *testing.T) {
+	tests := []struct {
+		path  string
+		valid bool
+	}{
+		{path: "", valid: true},
+		{path: "licensing/audit", valid: true},
+		{path: "/licensing/..audit", valid: true},
+		{path: "..", valid: false},
+		{path: "licensing/../../etc", valid: false},
+		{path: "/../licensing", valid: false},
+	}
+	for _, test := range tests {
+		spec := &IBMLicensingAuditSnapshotSpec{Storage: IBMLicensingAuditSnapshotStorage{
+			PVC: &IBMLicensingAuditSnapshotPVC{ClaimName: "audit-snapshots", Path: test.path},
+		}}
+		if err := spec.ValidateStorageAndDates(); (err == nil) != test.valid {
+			t.Errorf("path %q: expected valid %v, got error %v", test.path, test.valid, err)
+		}
+	}
+}
+
+func TestValidatePasswordCharset(t *testing.T) {
+	tests := []struct {
+		charset string
+		valid   bool
+	}{
+		{charset: DefaultPasswordCharset, valid: true},
+		{charset: "0123456789", valid: true},
+		{charset: "!#$%&()*+,-./:;<=>?@[]^_{|}~", valid: true},
+		{charset: "012345678", valid: false},
+		{charset: "00112233445566778888", valid: false},
+		{charset: "0123456789 ", valid: false},
+		{charset: "0123456789\t", valid: false},
+		{charset: "0123456789ąęłńóśźż", valid: false},
+	}
+	for _, test := range tests {
+		if err := ValidatePasswordCharset(test.charset); (err == nil) != test.valid {
+			t.Errorf("charset %q: expected valid %v, got error %v", test.charset, test.valid, err)
+		}
+	}
+}
@@ -40,6 +40,69 @@ type IBMLicenseServiceReporterSpec struct {
 	StorageClass string `json:"storageClass,omitempty"`
 	// Persistent Volume Claim Capacity
 	Capacity resource.Quantity `json:"capacity,omitempty" protobuf:"bytes,2,opt,name=capacity"`
+	// UI Settings
+	// +optional
+	UI *IBMLicenseServiceReporterUI `json:"ui,omitempty"`
+	// Actions performed by the operator before the instance is deleted
+	// +optional
+	Decommission *IBMLicenseServiceReporterDecommission `json:"decommission,omitempty"`
+	// Policy of passwords generated for the database and their rotation
+	// +optional
+	DatabasePassword *IBMLicenseServiceReporterDatabasePassword `json:"databasePassword,omitempty"`
+}
+
+// UIEnabledMode decides whether License Service Reporter is deployed with UI container
+// +kubebuilder:validation:Enum=auto;"true";"false"
+type UIEnabledMode string
+
+const (
+	// UIEnabledAuto deploys UI container when platform-oidc-credentials secret exists in the instance namespace
+	UIEnabledAuto UIEnabledMode = "auto"
+	// UIEnabledTrue always deploys UI container
+	UIEnabledTrue UIEnabledMode = "true"
+	// UIEnabledFalse never deploys UI container
+	UIEnabledFalse UIEnabledMode = "false"
+)
+
+// IBMLicenseServiceReporterUI configures UI of License Service Reporter
+type IBMLicenseServiceReporterUI struct {
+	// Whether UI container is deployed: auto (default) deploys it when platform-oidc-credentials secret exists,
+	// "true" and "false" always or never deploy it
+	// +optional
+	Enabled UIEnabledMode `json:"enabled,omitempty"`
+}
+
+// IBMLicenseServiceReporterDecommission configures cleanup done by the operator when IBMLicenseServiceReporter is deleted
+type IBMLicenseServiceReporterDecommission struct {
+	// Export data aggregated by License Service Reporter to a secret, which is not deleted with the instance,
+	// before the instance is deleted
+	// +optional
+	ExportOnDelete bool `json:"exportOnDelete,omitempty"`
+}
+
+// IBMLicenseServiceReporterDatabasePassword configures passwords of License Service Reporter database
+type IBMLicenseServiceReporterDatabasePassword struct {
+	// Time after which the operator changes the password in the running database, f.e. 2160h,
+	// the password is not rotated when not set
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+	// Length of generated passwords, defaults to 32
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	Length int `json:"length,omitempty"`
+	// Printable ASCII characters used in generated passwords, at least 10 different ones, defaults to letters and digits
+	// +kubebuilder:validation:Pattern=`^[!-~]+$`
+	// +optional
+	Charset string `json:"charset,omitempty"`
+}
+
+// DatabasePasswordStatus describes rotation of License Service Reporter database password
+type DatabasePasswordStatus struct {
+	// Time when the current password was set
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+	// Time after which the operator changes the password
+	NextRotationTime metav1.Time `json:"nextRotationTime"`
 }
 
 // IBMLicenseServiceReporterStatus defines the observed state of IBMLicenseServiceReporter
@@ -47,6 +110,25 @@ type IBMLicenseServiceReporterStatus struct {
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
 	LicensingReporterPods []corev1.PodStatus `json:"LicensingReporterPods"`
+
+	// Conditions of IBM License Service Reporter: Ready, Progressing, Degraded and conditions of its components:
+	// DatabaseReady, ReceiverReady, UIEnabled, StorageBound
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Generation of IBMLicenseServiceReporter which was reconciled the last time
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Certificates issued by CA maintained by the operator, when httpsCertsSource is operator-ca
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// Rotation of the database password, when databasePassword.rotationInterval is set
+	// +optional
+	DatabasePassword *DatabasePasswordStatus `json:"databasePassword,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -54,6 +136,9 @@ type IBMLicenseServiceReporterStatus struct {
 // IBMLicenseServiceReporter is the Schema for the ibmlicenseservicereporters API.
 // Documentation For additional details regarding install parameters check: https://ibm.biz/icpfs39install.
 // License By installing this product you accept the license terms https://ibm.biz/icpfs39license.
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="UI",type=string,JSONPath=`.status.conditions[?(@.type=="UIEnabled")].status`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=ibmlicenseservicereporters,scope=Namespaced
 type IBMLicenseServiceReporter struct {
@@ -137,6 +137,50 @@ type IBMLicensingSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sender",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
 	// +optional
 	Sender *IBMLicensingSenderSpec `json:"sender,omitempty"`
+
+	// Actions performed by the operator before the instance is deleted
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Decommission",xDescriptors="urn:alm:descriptor:com.tectonic.ui:hidden"
+	// +optional
+	Decommission *IBMLicensingDecommission `json:"decommission,omitempty"`
+
+	// Periodic rotation of API and upload tokens, tokens are not rotated when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token Rotation",xDescriptors="urn:alm:descriptor:com.tectonic.ui:hidden"
+	// +optional
+	TokenRotation *IBMLicensingTokenRotation `json:"tokenRotation,omitempty"`
+}
+
+// IBMLicensingDecommission configures cleanup done by the operator when IBMLicensing is deleted
+type IBMLicensingDecommission struct {
+	// Retrieve an audit snapshot from License Service and store it in a secret, which is not deleted with the instance,
+	// before the instance is deleted
+	// +optional
+	SnapshotOnDelete bool `json:"snapshotOnDelete,omitempty"`
+	// Name of secret in License Service namespace with ed25519 or RSA private key in PEM format under privateKey key,
+	// when set the audit snapshot is stored with SHA-256 manifest and its detached signature
+	// +optional
+	SigningKeySecret string `json:"signingKeySecret,omitempty"`
+}
+
+// IBMLicensingTokenRotation configures periodic rotation of API and upload tokens created by the operator
+type IBMLicensingTokenRotation struct {
+	// Time after which a new token is generated, f.e. 720h
+	Interval metav1.Duration `json:"interval"`
+	// Time during which the previous token stays valid after rotation, defaults to 24h
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// TokenRotationStatus describes rotation of token stored in a secret
+type TokenRotationStatus struct {
+	// Name of the secret with the token
+	SecretName string `json:"secretName"`
+	// Time when the current token was generated
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
+	// Time after which the operator generates a new token
+	NextRotationTime metav1.Time `json:"nextRotationTime"`
+	// Time when the previous token is removed from the secret, set during the grace period
+	// +optional
+	PreviousTokenExpiry *metav1.Time `json:"previousTokenExpiry,omitempty"`
 }
 
 type IBMLicensingSenderSpec struct {
@@ -170,6 +214,25 @@ type IBMLicensingSecurityContext struct {
 type IBMLicensingStatus struct {
 	// The status of IBM License Service Pods.
 	LicensingPods []corev1.PodStatus `json:"licensingPods"`
+
+	// Conditions of IBM License Service: Ready, Progressing and Degraded
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Generation of IBMLicensing which was reconciled the last time
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Certificates issued by CA maintained by the operator, when httpsCertsSource is operator-ca
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// Rotation of tokens created by the operator, when tokenRotation is set
+	// +optional
+	TokenRotation []TokenRotationStatus `json:"tokenRotation,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -178,8 +241,11 @@ type IBMLicensingStatus struct {
 // Documentation For additional details regarding install parameters check: https://ibm.biz/icpfs39install.
 // License By installing this product you accept the license terms https://ibm.biz/icpfs39license.
 // +kubebuilder:printcolumn:name="Pod Phase",type=string,JSONPath=`.status..phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=ibmlicensings,scope=Cluster
+// +kubebuilder:storageversion
 // +operator-sdk:csv:customresourcedefinitions:displayName="IBM License Service"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Service,v1,},{Pod,v1,}}
 // +operator-sdk:csv:customresourcedefinitions:resources={{Deployment,v1,},{Secret,v1,}}
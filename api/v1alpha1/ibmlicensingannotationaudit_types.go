@@ -0,0 +1,99 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBMLicensingAnnotationAuditSpec defines the desired state of IBMLicensingAnnotationAudit
+type IBMLicensingAnnotationAuditSpec struct {
+	// Selects namespaces in which pods are audited, when not set pods from all namespaces are audited
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// When true, Warning events are not emitted on pods with invalid licensing annotations
+	// +optional
+	DisableEvents bool `json:"disableEvents,omitempty"`
+}
+
+// IBMLicensingAnnotationAuditFinding describes problems with licensing annotations of a single pod
+type IBMLicensingAnnotationAuditFinding struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	// List of problems found in licensing annotations of the pod
+	Problems []string `json:"problems"`
+}
+
+// IBMLicensingAnnotationAuditStatus defines the observed state of IBMLicensingAnnotationAudit
+type IBMLicensingAnnotationAuditStatus struct {
+	// Number of audited pods that have licensing annotations
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Licensed Pods"
+	// +optional
+	LicensedPods int32 `json:"licensedPods"`
+	// Number of pods with invalid licensing annotations
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Invalid Pods"
+	// +optional
+	InvalidPods int32 `json:"invalidPods"`
+	// Pods with invalid licensing annotations, limited to first 50 pods in namespace and name alphabetical order
+	// +optional
+	Findings []IBMLicensingAnnotationAuditFinding `json:"findings,omitempty"`
+	// Time of the last audit that changed the results
+	// +optional
+	LastAuditTime *metav1.Time `json:"lastAuditTime,omitempty"`
+	// Conditions represent the latest available observations of IBMLicensingAnnotationAudit state
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Conditions"
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// IBMLicensingAnnotationAudit enables audit of licensing annotations of pods. Pods with malformed or incomplete
+// licensing annotations, which License Service can not count correctly, are reported in status and with Warning events.
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=ibmlicensingannotationaudits,scope=Cluster
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Licensed Pods",type=integer,JSONPath=`.status.licensedPods`
+// +kubebuilder:printcolumn:name="Invalid Pods",type=integer,JSONPath=`.status.invalidPods`
+// +kubebuilder:printcolumn:name="Last Change",type=date,JSONPath=`.status.lastAuditTime`
+type IBMLicensingAnnotationAudit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBMLicensingAnnotationAuditSpec   `json:"spec,omitempty"`
+	Status IBMLicensingAnnotationAuditStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IBMLicensingAnnotationAuditList contains a list of IBMLicensingAnnotationAudit
+type IBMLicensingAnnotationAuditList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBMLicensingAnnotationAudit `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBMLicensingAnnotationAudit{}, &IBMLicensingAnnotationAuditList{})
+}
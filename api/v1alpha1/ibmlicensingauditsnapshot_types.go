@@ -0,0 +1,187 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IBMLicensingAuditSnapshotSpec defines the desired state of IBMLicensingAuditSnapshot
+type IBMLicensingAuditSnapshotSpec struct {
+	// Schedule of audit snapshots in cron format, f.e. "0 0 1 * *" for the first day of every month
+	Schedule string `json:"schedule"`
+	// Name of IBMLicensing instance from which audit snapshots are retrieved, defaults to instance
+	// +optional
+	Licensing string `json:"licensing,omitempty"`
+	// Range of days covered by audit snapshots, when not set License Service default range is used
+	// +optional
+	DateRange *IBMLicensingAuditSnapshotDateRange `json:"dateRange,omitempty"`
+	// Storage of audit snapshot archives, either pvc or s3 must be set
+	Storage IBMLicensingAuditSnapshotStorage `json:"storage"`
+	// Number of audit snapshot archives kept in the storage, older archives are deleted, defaults to 12
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Retention *int32 `json:"retention,omitempty"`
+	// When true, no new audit snapshots are scheduled
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// Name of secret in the namespace of IBMLicensingAuditSnapshot with ed25519 or RSA private key in PEM format under
+	// privateKey key, when set every archive is stored with SHA-256 manifest and its detached signature
+	// +optional
+	SigningKeySecret string `json:"signingKeySecret,omitempty"`
+}
+
+// IBMLicensingAuditSnapshotDateRange defines days covered by audit snapshot, either lastDays or start and end can be set
+type IBMLicensingAuditSnapshotDateRange struct {
+	// Number of days before the day of the snapshot covered by it
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LastDays *int32 `json:"lastDays,omitempty"`
+	// First day covered by audit snapshots, in YYYY-MM-DD format
+	// +kubebuilder:validation:Pattern=`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	// +optional
+	Start string `json:"start,omitempty"`
+	// Last day covered by audit snapshots, in YYYY-MM-DD format
+	// +kubebuilder:validation:Pattern=`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	// +optional
+	End string `json:"end,omitempty"`
+}
+
+// IBMLicensingAuditSnapshotStorage defines where audit snapshot archives are stored
+type IBMLicensingAuditSnapshotStorage struct {
+	// Persistent volume claim in the namespace of IBMLicensingAuditSnapshot
+	// +optional
+	PVC *IBMLicensingAuditSnapshotPVC `json:"pvc,omitempty"`
+	// S3-compatible object storage
+	// +optional
+	S3 *IBMLicensingAuditSnapshotS3 `json:"s3,omitempty"`
+}
+
+// IBMLicensingAuditSnapshotPVC defines persistent volume claim storing audit snapshot archives
+type IBMLicensingAuditSnapshotPVC struct {
+	// Name of persistent volume claim
+	ClaimName string `json:"claimName"`
+	// Directory in the volume where archives are stored, defaults to the root of the volume
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// IBMLicensingAuditSnapshotS3 defines S3-compatible bucket storing audit snapshot archives
+type IBMLicensingAuditSnapshotS3 struct {
+	// URL of S3-compatible endpoint, f.e. https://s3.us-east-1.amazonaws.com
+	Endpoint string `json:"endpoint"`
+	// Name of the bucket
+	Bucket string `json:"bucket"`
+	// Region of the bucket, defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Prefix of object keys of archives
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Name of secret in the namespace of IBMLicensingAuditSnapshot with accessKeyID and secretAccessKey keys
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// AuditSnapshotOutcome is result of a single audit snapshot run
+type AuditSnapshotOutcome string
+
+const (
+	AuditSnapshotSucceeded AuditSnapshotOutcome = "Succeeded"
+	AuditSnapshotFailed    AuditSnapshotOutcome = "Failed"
+)
+
+// IBMLicensingAuditSnapshotRun describes a single audit snapshot run
+type IBMLicensingAuditSnapshotRun struct {
+	// Name of the job which retrieved the audit snapshot
+	Job string `json:"job"`
+	// Time for which the run was scheduled
+	ScheduleTime metav1.Time `json:"scheduleTime"`
+	// Time when the run finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Outcome of the run, Succeeded or Failed
+	Outcome AuditSnapshotOutcome `json:"outcome"`
+	// Details of failure
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Location of stored archive, path in the volume or S3 object URL
+	// +optional
+	Location string `json:"location,omitempty"`
+	// Size of the archive in bytes
+	// +optional
+	Size int64 `json:"size,omitempty"`
+	// SHA-256 checksum of the archive in hex
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// Location of detached signature of the archive manifest, set when the archive is signed
+	// +optional
+	Signature string `json:"signature,omitempty"`
+}
+
+// IBMLicensingAuditSnapshotStatus defines the observed state of IBMLicensingAuditSnapshot
+type IBMLicensingAuditSnapshotStatus struct {
+	// Time of the last scheduled run
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// Time of the last successful run
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// Name of the job of the run in progress
+	// +optional
+	Active string `json:"active,omitempty"`
+	// Finished runs, newest first, limited to 10 runs
+	// +optional
+	Runs []IBMLicensingAuditSnapshotRun `json:"runs,omitempty"`
+	// Conditions represent the latest available observations of IBMLicensingAuditSnapshot state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// IBMLicensingAuditSnapshot retrieves audit snapshots from License Service on schedule and stores them in a persistent
+// volume or S3-compatible object storage, keeping the configured number of archives.
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=ibmlicensingauditsnapshots,scope=Namespaced
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Last Outcome",type=string,JSONPath=`.status.runs[0].outcome`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+type IBMLicensingAuditSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IBMLicensingAuditSnapshotSpec   `json:"spec,omitempty"`
+	Status IBMLicensingAuditSnapshotStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IBMLicensingAuditSnapshotList contains a list of IBMLicensingAuditSnapshot
+type IBMLicensingAuditSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IBMLicensingAuditSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IBMLicensingAuditSnapshot{}, &IBMLicensingAuditSnapshotList{})
+}
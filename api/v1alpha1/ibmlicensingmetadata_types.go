@@ -20,9 +20,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// IBMLicensingMetadataCondition selects pods that are extended, pod has to match all of the specified fields
 type IBMLicensingMetadataCondition struct {
 	// List of annotations used for matching pod
-	Annotation map[string]string `json:"annotation"`
+	// +optional
+	Annotation map[string]string `json:"annotation,omitempty"`
+	// Selects pods by labels, when not set pods are not filtered by labels
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Selects namespaces in which pods are matched, when not set only pods from namespace of IBMLicensingMetadata are matched.
+	// Empty selector matches all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // IBMLicensingMetadataSpec defines the desired state of IBMLicensingMetadata
@@ -30,10 +39,56 @@ type IBMLicensingMetadataSpec struct {
 	Condition IBMLicensingMetadataCondition `json:"condition"`
 	// List of annotations that matched pod would be extended
 	Extend map[string]string `json:"extend"`
+	// When multiple IBMLicensingMetadata match the same pod and extend the same annotation with different values,
+	// value from IBMLicensingMetadata with higher priority is used. When priorities are equal, IBMLicensingMetadata
+	// that is first in namespace and name alphabetical order is used.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Defines what pod mutating webhook does when pod already has licensing annotation with different value:
+	// Keep (default) leaves pod value, Overwrite replaces it with value from extend, Reject denies pod creation
+	// +kubebuilder:validation:Enum=Keep;Overwrite;Reject
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+	// When true, pod mutating webhook does not change pods and only emits events about annotations it would apply
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
+// ConflictPolicy describes how pod mutating webhook resolves annotations already set on pod
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyKeep means annotation already set on pod is kept
+	ConflictPolicyKeep ConflictPolicy = "Keep"
+	// ConflictPolicyOverwrite means annotation already set on pod is replaced with value from extend
+	ConflictPolicyOverwrite ConflictPolicy = "Overwrite"
+	// ConflictPolicyReject means pod creation is denied
+	ConflictPolicyReject ConflictPolicy = "Reject"
+)
+
 // IBMLicensingMetadataStatus defines the observed state of IBMLicensingMetadata
 type IBMLicensingMetadataStatus struct {
+	// Number of pods matching the condition
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Matched Pods"
+	// +optional
+	MatchedPods int32 `json:"matchedPods"`
+	// Sample of names of pods matching the condition, limited to first 10 pods in alphabetical order
+	// +optional
+	MatchedPodNames []string `json:"matchedPodNames,omitempty"`
+	// Errors found during validation of condition and extend annotations against licensing annotations schema
+	// +optional
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+	// Conditions represent the latest available observations of IBMLicensingMetadata state
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.displayName="Conditions"
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -45,6 +100,10 @@ type IBMLicensingMetadataStatus struct {
 // see https://ibm.biz/icpfs39license.
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=ibmlicensingmetadatas,scope=Namespaced
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Matched Pods",type=integer,JSONPath=`.status.matchedPods`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+// +kubebuilder:printcolumn:name="Conflicting",type=string,JSONPath=`.status.conditions[?(@.type=="Conflicting")].status`
 type IBMLicensingMetadata struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -39,9 +39,42 @@ import (
 	v1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuer) DeepCopyInto(out *CertManagerIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuer.
+func (in *CertManagerIssuer) DeepCopy() *CertManagerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	in.RenewalTime.DeepCopyInto(&out.RenewalTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Container) DeepCopyInto(out *Container) {
 	*out = *in
@@ -58,6 +91,23 @@ func (in *Container) DeepCopy() *Container {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabasePasswordStatus) DeepCopyInto(out *DatabasePasswordStatus) {
+	*out = *in
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	in.NextRotationTime.DeepCopyInto(&out.NextRotationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabasePasswordStatus.
+func (in *DatabasePasswordStatus) DeepCopy() *DatabasePasswordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabasePasswordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMLicenseServiceBaseSpec) DeepCopyInto(out *IBMLicenseServiceBaseSpec) {
 	*out = *in
@@ -66,6 +116,11 @@ func (in *IBMLicenseServiceBaseSpec) DeepCopyInto(out *IBMLicenseServiceBaseSpec
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CertManagerIssuer != nil {
+		in, out := &in.CertManagerIssuer, &out.CertManagerIssuer
+		*out = new(CertManagerIssuer)
+		**out = **in
+	}
 	if in.RouteOptions != nil {
 		in, out := &in.RouteOptions, &out.RouteOptions
 		*out = new(IBMLicenseServiceRouteOptions)
@@ -110,6 +165,41 @@ func (in *IBMLicenseServiceReporter) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicenseServiceReporterDatabasePassword) DeepCopyInto(out *IBMLicenseServiceReporterDatabasePassword) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicenseServiceReporterDatabasePassword.
+func (in *IBMLicenseServiceReporterDatabasePassword) DeepCopy() *IBMLicenseServiceReporterDatabasePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicenseServiceReporterDatabasePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicenseServiceReporterDecommission) DeepCopyInto(out *IBMLicenseServiceReporterDecommission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicenseServiceReporterDecommission.
+func (in *IBMLicenseServiceReporterDecommission) DeepCopy() *IBMLicenseServiceReporterDecommission {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicenseServiceReporterDecommission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMLicenseServiceReporterList) DeepCopyInto(out *IBMLicenseServiceReporterList) {
 	*out = *in
@@ -157,6 +247,21 @@ func (in *IBMLicenseServiceReporterSpec) DeepCopyInto(out *IBMLicenseServiceRepo
 	in.DatabaseContainer.DeepCopyInto(&out.DatabaseContainer)
 	in.IBMLicenseServiceBaseSpec.DeepCopyInto(&out.IBMLicenseServiceBaseSpec)
 	out.Capacity = in.Capacity.DeepCopy()
+	if in.UI != nil {
+		in, out := &in.UI, &out.UI
+		*out = new(IBMLicenseServiceReporterUI)
+		**out = **in
+	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(IBMLicenseServiceReporterDecommission)
+		**out = **in
+	}
+	if in.DatabasePassword != nil {
+		in, out := &in.DatabasePassword, &out.DatabasePassword
+		*out = new(IBMLicenseServiceReporterDatabasePassword)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicenseServiceReporterSpec.
@@ -179,6 +284,25 @@ func (in *IBMLicenseServiceReporterStatus) DeepCopyInto(out *IBMLicenseServiceRe
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DatabasePassword != nil {
+		in, out := &in.DatabasePassword, &out.DatabasePassword
+		*out = new(DatabasePasswordStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicenseServiceReporterStatus.
@@ -191,6 +315,21 @@ func (in *IBMLicenseServiceReporterStatus) DeepCopy() *IBMLicenseServiceReporter
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicenseServiceReporterUI) DeepCopyInto(out *IBMLicenseServiceReporterUI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicenseServiceReporterUI.
+func (in *IBMLicenseServiceReporterUI) DeepCopy() *IBMLicenseServiceReporterUI {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicenseServiceReporterUI)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMLicenseServiceRouteOptions) DeepCopyInto(out *IBMLicenseServiceRouteOptions) {
 	*out = *in
@@ -238,6 +377,370 @@ func (in *IBMLicensing) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAnnotationAudit) DeepCopyInto(out *IBMLicensingAnnotationAudit) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAnnotationAudit.
+func (in *IBMLicensingAnnotationAudit) DeepCopy() *IBMLicensingAnnotationAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAnnotationAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensingAnnotationAudit) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAnnotationAuditFinding) DeepCopyInto(out *IBMLicensingAnnotationAuditFinding) {
+	*out = *in
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAnnotationAuditFinding.
+func (in *IBMLicensingAnnotationAuditFinding) DeepCopy() *IBMLicensingAnnotationAuditFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAnnotationAuditFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAnnotationAuditList) DeepCopyInto(out *IBMLicensingAnnotationAuditList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBMLicensingAnnotationAudit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAnnotationAuditList.
+func (in *IBMLicensingAnnotationAuditList) DeepCopy() *IBMLicensingAnnotationAuditList {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAnnotationAuditList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensingAnnotationAuditList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAnnotationAuditSpec) DeepCopyInto(out *IBMLicensingAnnotationAuditSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAnnotationAuditSpec.
+func (in *IBMLicensingAnnotationAuditSpec) DeepCopy() *IBMLicensingAnnotationAuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAnnotationAuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAnnotationAuditStatus) DeepCopyInto(out *IBMLicensingAnnotationAuditStatus) {
+	*out = *in
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]IBMLicensingAnnotationAuditFinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAuditTime != nil {
+		in, out := &in.LastAuditTime, &out.LastAuditTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAnnotationAuditStatus.
+func (in *IBMLicensingAnnotationAuditStatus) DeepCopy() *IBMLicensingAnnotationAuditStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAnnotationAuditStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshot) DeepCopyInto(out *IBMLicensingAuditSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshot.
+func (in *IBMLicensingAuditSnapshot) DeepCopy() *IBMLicensingAuditSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensingAuditSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotDateRange) DeepCopyInto(out *IBMLicensingAuditSnapshotDateRange) {
+	*out = *in
+	if in.LastDays != nil {
+		in, out := &in.LastDays, &out.LastDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotDateRange.
+func (in *IBMLicensingAuditSnapshotDateRange) DeepCopy() *IBMLicensingAuditSnapshotDateRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotDateRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotList) DeepCopyInto(out *IBMLicensingAuditSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IBMLicensingAuditSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotList.
+func (in *IBMLicensingAuditSnapshotList) DeepCopy() *IBMLicensingAuditSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IBMLicensingAuditSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotPVC) DeepCopyInto(out *IBMLicensingAuditSnapshotPVC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotPVC.
+func (in *IBMLicensingAuditSnapshotPVC) DeepCopy() *IBMLicensingAuditSnapshotPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotRun) DeepCopyInto(out *IBMLicensingAuditSnapshotRun) {
+	*out = *in
+	in.ScheduleTime.DeepCopyInto(&out.ScheduleTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotRun.
+func (in *IBMLicensingAuditSnapshotRun) DeepCopy() *IBMLicensingAuditSnapshotRun {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotS3) DeepCopyInto(out *IBMLicensingAuditSnapshotS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotS3.
+func (in *IBMLicensingAuditSnapshotS3) DeepCopy() *IBMLicensingAuditSnapshotS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotSpec) DeepCopyInto(out *IBMLicensingAuditSnapshotSpec) {
+	*out = *in
+	if in.DateRange != nil {
+		in, out := &in.DateRange, &out.DateRange
+		*out = new(IBMLicensingAuditSnapshotDateRange)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotSpec.
+func (in *IBMLicensingAuditSnapshotSpec) DeepCopy() *IBMLicensingAuditSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotStatus) DeepCopyInto(out *IBMLicensingAuditSnapshotStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make([]IBMLicensingAuditSnapshotRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotStatus.
+func (in *IBMLicensingAuditSnapshotStatus) DeepCopy() *IBMLicensingAuditSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingAuditSnapshotStorage) DeepCopyInto(out *IBMLicensingAuditSnapshotStorage) {
+	*out = *in
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(IBMLicensingAuditSnapshotPVC)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(IBMLicensingAuditSnapshotS3)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingAuditSnapshotStorage.
+func (in *IBMLicensingAuditSnapshotStorage) DeepCopy() *IBMLicensingAuditSnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingAuditSnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingDecommission) DeepCopyInto(out *IBMLicensingDecommission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingDecommission.
+func (in *IBMLicensingDecommission) DeepCopy() *IBMLicensingDecommission {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingDecommission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMLicensingIngressOptions) DeepCopyInto(out *IBMLicensingIngressOptions) {
 	*out = *in
@@ -315,7 +818,7 @@ func (in *IBMLicensingMetadata) DeepCopyInto(out *IBMLicensingMetadata) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingMetadata.
@@ -346,6 +849,16 @@ func (in *IBMLicensingMetadataCondition) DeepCopyInto(out *IBMLicensingMetadataC
 			(*out)[key] = val
 		}
 	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingMetadataCondition.
@@ -416,6 +929,23 @@ func (in *IBMLicensingMetadataSpec) DeepCopy() *IBMLicensingMetadataSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IBMLicensingMetadataStatus) DeepCopyInto(out *IBMLicensingMetadataStatus) {
 	*out = *in
+	if in.MatchedPodNames != nil {
+		in, out := &in.MatchedPodNames, &out.MatchedPodNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingMetadataStatus.
@@ -531,6 +1061,16 @@ func (in *IBMLicensingSpec) DeepCopyInto(out *IBMLicensingSpec) {
 		*out = new(IBMLicensingSenderSpec)
 		**out = **in
 	}
+	if in.Decommission != nil {
+		in, out := &in.Decommission, &out.Decommission
+		*out = new(IBMLicensingDecommission)
+		**out = **in
+	}
+	if in.TokenRotation != nil {
+		in, out := &in.TokenRotation, &out.TokenRotation
+		*out = new(IBMLicensingTokenRotation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingSpec.
@@ -553,6 +1093,27 @@ func (in *IBMLicensingStatus) DeepCopyInto(out *IBMLicensingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TokenRotation != nil {
+		in, out := &in.TokenRotation, &out.TokenRotation
+		*out = make([]TokenRotationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingStatus.
@@ -564,3 +1125,45 @@ func (in *IBMLicensingStatus) DeepCopy() *IBMLicensingStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBMLicensingTokenRotation) DeepCopyInto(out *IBMLicensingTokenRotation) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBMLicensingTokenRotation.
+func (in *IBMLicensingTokenRotation) DeepCopy() *IBMLicensingTokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(IBMLicensingTokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenRotationStatus) DeepCopyInto(out *TokenRotationStatus) {
+	*out = *in
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	in.NextRotationTime.DeepCopyInto(&out.NextRotationTime)
+	if in.PreviousTokenExpiry != nil {
+		in, out := &in.PreviousTokenExpiry, &out.PreviousTokenExpiry
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenRotationStatus.
+func (in *TokenRotationStatus) DeepCopy() *TokenRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,84 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
+)
+
+// terminationMessagePath is where the result of audit snapshot job is written for the operator
+const terminationMessagePath = "/dev/termination-log"
+
+// runAuditSnapshot retrieves audit snapshot from License Service and stores it, it is run by jobs of IBMLicensingAuditSnapshot,
+// secrets are passed in environment variables
+func runAuditSnapshot(args []string) error {
+	options := snapshot.Options{Token: os.Getenv(snapshot.TokenEnvVar), SigningKey: []byte(os.Getenv(snapshot.SigningKeyEnvVar))}
+	var pvcStorage snapshot.VolumeStorage
+	var s3Storage snapshot.S3Storage
+	var resultFile string
+	flags := flag.NewFlagSet(snapshot.Command, flag.ContinueOnError)
+	flags.StringVar(&options.URL, "url", "", "URL of License Service snapshot API.")
+	flags.StringVar(&options.Start, "start", "", "First day covered by audit snapshot, in YYYY-MM-DD format.")
+	flags.StringVar(&options.End, "end", "", "Last day covered by audit snapshot, in YYYY-MM-DD format.")
+	flags.StringVar(&options.Name, "name", "", "Name of stored archive.")
+	flags.IntVar(&options.Retention, "retention", 12, "Number of archives kept in the storage.")
+	flags.StringVar(&pvcStorage.ClaimName, "pvc-claim", "", "Persistent volume claim mounted in "+snapshot.VolumeMountPath+".")
+	flags.StringVar(&pvcStorage.Path, "pvc-path", "", "Directory in the volume where archives are stored.")
+	flags.StringVar(&s3Storage.Endpoint, "s3-endpoint", "", "URL of S3-compatible endpoint.")
+	flags.StringVar(&s3Storage.Bucket, "s3-bucket", "", "Bucket where archives are stored.")
+	flags.StringVar(&s3Storage.Region, "s3-region", "us-east-1", "Region of the bucket.")
+	flags.StringVar(&s3Storage.Prefix, "s3-prefix", "", "Prefix of object keys of archives.")
+	flags.StringVar(&resultFile, "result-file", terminationMessagePath, "File where result is written in JSON format.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case options.URL == "" || options.Name == "":
+		return errors.New("--url and --name must be set")
+	case pvcStorage.ClaimName != "":
+		pvcStorage.MountPath = snapshot.VolumeMountPath
+		options.Storage = &pvcStorage
+	case s3Storage.Endpoint != "":
+		s3Storage.AccessKeyID = os.Getenv(snapshot.S3AccessKeyIDEnvVar)
+		s3Storage.SecretAccessKey = os.Getenv(snapshot.S3SecretAccessKeyEnvVar)
+		options.Storage = &s3Storage
+	default:
+		return errors.New("either --pvc-claim or --s3-endpoint must be set")
+	}
+
+	result, err := snapshot.Take(options)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	output, marshalErr := json.Marshal(result)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	fmt.Println(string(output))
+	if writeErr := ioutil.WriteFile(resultFile, output, 0600); writeErr != nil {
+		fmt.Fprintln(os.Stderr, "failed to write result:", writeErr)
+	}
+	return err
+}
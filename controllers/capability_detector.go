@@ -0,0 +1,231 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
+	"github.com/prometheus/client_golang/prometheus"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultCapabilityRefreshInterval is the default interval of periodic capabilities discovery
+const DefaultCapabilityRefreshInterval = 5 * time.Minute
+
+// capabilityRetryInterval is the delay of the next discovery, when CRD of optional API is not served yet
+const capabilityRetryInterval = 10 * time.Second
+
+var capabilityGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ibm_licensing_operator_cluster_capability",
+	Help: "Optional API detected by the operator in the cluster, 1 when the API is available and 0 otherwise",
+}, []string{"capability"})
+
+func init() {
+	metrics.Registry.MustRegister(capabilityGauge)
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+
+// CapabilityDetector discovers optional APIs of the cluster with the discovery API. Capabilities are discovered
+// periodically and when CRDs of optional APIs are created or deleted, and subscribers are notified when they change.
+type CapabilityDetector struct {
+	Client    client.Client
+	Discovery discovery.DiscoveryInterface
+	Log       logr.Logger
+	// Interval of periodic discovery, DefaultCapabilityRefreshInterval is used when not set
+	Interval time.Duration
+
+	mutex        sync.RWMutex
+	capabilities res.Capabilities
+	detected     bool
+	subscribers  []chan event.GenericEvent
+}
+
+// NewCapabilityDetector returns CapabilityDetector using discovery client for given config
+func NewCapabilityDetector(config *rest.Config, log logr.Logger) (*CapabilityDetector, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &CapabilityDetector{Discovery: discoveryClient, Log: log}, nil
+}
+
+// blank assignments to verify that CapabilityDetector implements manager.Runnable and reconcile.Reconciler
+var _ manager.Runnable = &CapabilityDetector{}
+var _ manager.LeaderElectionRunnable = &CapabilityDetector{}
+var _ reconcile.Reconciler = &CapabilityDetector{}
+
+// Get returns the latest detected capabilities
+func (d *CapabilityDetector) Get() res.Capabilities {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.capabilities
+}
+
+// Subscribe returns channel, which receives an event every time capabilities change
+func (d *CapabilityDetector) Subscribe() <-chan event.GenericEvent {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	subscriber := make(chan event.GenericEvent, 1)
+	d.subscribers = append(d.subscribers, subscriber)
+	return subscriber
+}
+
+// Refresh discovers capabilities, availability of APIs which can not be discovered because of an error is not changed
+func (d *CapabilityDetector) Refresh() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	capabilities := d.capabilities
+	var errs []error
+	for _, resource := range res.CapabilityResources {
+		available, err := d.isServed(resource)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resource.SetAvailable(&capabilities, available)
+		if available {
+			capabilityGauge.WithLabelValues(resource.Name).Set(1)
+		} else {
+			capabilityGauge.WithLabelValues(resource.Name).Set(0)
+		}
+	}
+
+	if !d.detected || capabilities != d.capabilities {
+		d.Log.Info("Detected cluster capabilities", "capabilities", capabilities)
+		if d.detected {
+			d.notify()
+		}
+		d.capabilities = capabilities
+		d.detected = true
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (d *CapabilityDetector) isServed(resource res.CapabilityResource) (bool, error) {
+	resourceList, err := d.Discovery.ServerResourcesForGroupVersion(resource.GroupVersion.String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, apiResource := range resourceList.APIResources {
+		if apiResource.Name == resource.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// notify sends event to subscribers, which did not receive the previous one yet
+func (d *CapabilityDetector) notify() {
+	capabilitiesEvent := event.GenericEvent{Meta: &metav1.ObjectMeta{Name: "capabilities"}}
+	for _, subscriber := range d.subscribers {
+		select {
+		case subscriber <- capabilitiesEvent:
+		default:
+		}
+	}
+}
+
+// Start discovers capabilities periodically until the operator is stopped
+func (d *CapabilityDetector) Start(stop <-chan struct{}) error {
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultCapabilityRefreshInterval
+	}
+	wait.Until(func() {
+		if err := d.Refresh(); err != nil {
+			d.Log.Error(err, "Failed to discover cluster capabilities")
+		}
+	}, interval, stop)
+	return nil
+}
+
+// NeedLeaderElection returns false, as capabilities are used by webhooks also when the operator is not the leader
+func (d *CapabilityDetector) NeedLeaderElection() bool {
+	return false
+}
+
+// SetupWithManager adds periodic discovery to the manager and watches CRDs of optional APIs
+func (d *CapabilityDetector) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(d); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capabilitydetector").
+		For(&apiextensionsv1.CustomResourceDefinition{}, builder.WithPredicates(predicate.NewPredicateFuncs(
+			func(meta metav1.Object, _ runtime.Object) bool {
+				_, found := capabilityResourceForCRD(meta.GetName())
+				return found
+			}))).
+		Complete(d)
+}
+
+// Reconcile discovers capabilities when CRD of optional API changes, discovery is retried until new API is served
+func (d *CapabilityDetector) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	if err := d.Refresh(); err != nil {
+		return reconcile.Result{}, err
+	}
+	resource, found := capabilityResourceForCRD(req.Name)
+	if !found {
+		return reconcile.Result{}, nil
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	err := d.Client.Get(context.TODO(), req.NamespacedName, crd)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if crd.GetDeletionTimestamp() == nil && !resource.IsAvailable(d.Get()) {
+		d.Log.Info("CRD of optional API is not served yet", "crd", req.Name)
+		return reconcile.Result{RequeueAfter: capabilityRetryInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func capabilityResourceForCRD(name string) (res.CapabilityResource, bool) {
+	for _, resource := range res.CapabilityResources {
+		if resource.CRDName() == name {
+			return resource, true
+		}
+	}
+	return res.CapabilityResource{}, false
+}
@@ -0,0 +1,49 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// clusterCache is informer cache of all namespaces, which runs next to the manager cache limited to the watched namespace
+type clusterCache struct {
+	cache.Cache
+}
+
+// NeedLeaderElection returns false, as the cache is used by webhooks also when the operator is not the leader
+func (c *clusterCache) NeedLeaderElection() bool {
+	return false
+}
+
+// NewClusterCache returns cache of all namespaces for resources of application namespaces, such as pods and
+// IBMLicensingMetadata. The manager cache is returned when it is not limited to the watched namespace.
+func NewClusterCache(mgr ctrl.Manager, watchNamespace string) (cache.Cache, error) {
+	if watchNamespace == "" {
+		return mgr.GetCache(), nil
+	}
+	informerCache, err := cache.New(mgr.GetConfig(), cache.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return nil, err
+	}
+	c := &clusterCache{Cache: informerCache}
+	if err := mgr.Add(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -19,29 +19,30 @@ package controllers
 import (
 	"context"
 	"reflect"
+	"strings"
 	"time"
 
-	networkingv1 "k8s.io/api/networking/v1"
-
-	routev1 "github.com/openshift/api/route/v1"
-
+	odlm "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
 	"github.com/go-logr/logr"
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/controllers/resources/reporter"
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-
-	odlm "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 /**
@@ -50,22 +51,92 @@ import (
  */
 
 func (r *IBMLicenseServiceReporterReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := res.UpdateCacheClusterExtensions(mgr.GetAPIReader()); err != nil {
-		r.Log.Error(err, "Error during checking K8s API")
-	}
+	capabilities := r.Capabilities.Get()
 
 	watcher := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options.controllerOptions()).
 		For(&operatorv1alpha1.IBMLicenseServiceReporter{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{})
-
-	if res.IsRouteAPI {
-		watcher.Owns(&operatorv1alpha1.IBMLicenseServiceReporter{})
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapReferencedSecret)}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapReferencedConfig)}).
+		Watches(&source.Channel{Source: r.Capabilities.Subscribe()},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapAllInstances)})
+
+	// watches are set up for APIs available when the operator starts
+	if capabilities.RouteAPI {
+		watcher.Owns(&routev1.Route{})
+	}
+	if capabilities.ODLM {
+		watcher.Owns(&odlm.OperandBindInfo{})
+	}
+	if capabilities.CertManagerAPI {
+		watcher.Owns(res.NewCertificate())
 	}
 
 	return watcher.Complete(r)
 }
 
+// mapReferencedSecret maps secrets, which are used but not created by the operator, to IBMLicenseServiceReporter
+// instances from the same namespace, other secrets are mapped when they are used by License Service Reporter pods
+func (r *IBMLicenseServiceReporterReconciler) mapReferencedSecret(object handler.MapObject) []reconcile.Request {
+	if object.Meta.GetName() != res.UIPlatformSecretName {
+		return r.mapReferencedConfig(object)
+	}
+	return r.mapInstances(client.InNamespace(object.Meta.GetNamespace()))
+}
+
+// mapReferencedConfig maps secrets and configmaps used by License Service Reporter pods to IBMLicenseServiceReporter
+// instances controlling the pods, so that pods are rolled out when their content changes
+func (r *IBMLicenseServiceReporterReconciler) mapReferencedConfig(object handler.MapObject) []reconcile.Request {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(context.TODO(), deployments, client.InNamespace(object.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list deployments")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range deployments.Items {
+		controllerRef := metav1.GetControllerOf(&deployments.Items[i])
+		if controllerRef == nil || controllerRef.Kind != "IBMLicenseServiceReporter" {
+			continue
+		}
+		if res.GetConfigReferences(deployments.Items[i].Spec.Template.Spec).Contains(object.Object, object.Meta.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: controllerRef.Name, Namespace: object.Meta.GetNamespace()}})
+		}
+	}
+	return requests
+}
+
+// mapAllInstances maps an event, f.e. change of cluster capabilities, to all IBMLicenseServiceReporter instances
+func (r *IBMLicenseServiceReporterReconciler) mapAllInstances(handler.MapObject) []reconcile.Request {
+	return r.mapInstances()
+}
+
+// mapInstances returns requests for IBMLicenseServiceReporter instances matching list options
+func (r *IBMLicenseServiceReporterReconciler) mapInstances(opts ...client.ListOption) []reconcile.Request {
+	instances := &operatorv1alpha1.IBMLicenseServiceReporterList{}
+	if err := r.Client.List(context.TODO(), instances, opts...); err != nil {
+		r.Log.Error(err, "Failed to list IBMLicenseServiceReporter instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, instance := range instances.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}})
+	}
+	return requests
+}
+
 // blank assignment to verify that IBMLicenseServiceReporterReconciler implements reconcile.Reconciler
 var _ reconcile.Reconciler = &IBMLicenseServiceReporterReconciler{}
 
@@ -75,12 +146,22 @@ type IBMLicenseServiceReporterReconciler struct {
 	// that reads objects from the cache and writes to the apiserver
 	client.Client
 	client.Reader
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+	Options      ReconcileOptions
+	Capabilities *CapabilityDetector
+	Executor     PodExecutor
 }
 
 type reconcileLRFunctionType = func(*operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error)
 
+// reconcileLRStep is a single step of IBMLicenseServiceReporter reconciliation, component is used in status condition reasons
+type reconcileLRStep struct {
+	reconcile reconcileLRFunctionType
+	component string
+}
+
 // Reconcile reads that state of the cluster for a IBMLicenseServiceReporter object and makes changes based on the state read
 // and what is in the IBMLicenseServiceReporter.Spec
 // a Pod as an example
@@ -92,6 +173,7 @@ type reconcileLRFunctionType = func(*operatorv1alpha1.IBMLicenseServiceReporter)
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="",resources=pods;services;services/finalizers;endpoints;persistentvolumeclaims;events;configmaps;secrets;namespaces;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=operator.ibm.com,resources=ibmlicenseservicereporters;ibmlicenseservicereporters/status;ibmlicenseservicereporters/finalizers;operandbindinfos,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.ibm.com,resources=ibmlicenseservicereporters;ibmlicenseservicereporters/status;ibmlicenseservicereporters/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:namespace=ibm-common-services,groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 func (r *IBMLicenseServiceReporterReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("Request", req)
@@ -100,26 +182,23 @@ func (r *IBMLicenseServiceReporterReconciler) Reconcile(req reconcile.Request) (
 	var recResult reconcile.Result
 	var recErr error
 
-	if err := res.UpdateCacheClusterExtensions(r.Reader); err != nil {
-		reqLogger.Error(err, "Error during checking K8s API")
-	}
-
-	reconcileFunctions := []interface{}{
-		r.reconcileServiceAccount,
-		r.reconcileRole,
-		r.reconcileRoleBinding,
-		r.reconcileAPISecretToken,
-		r.reconcileDatabaseSecret,
-		r.reconcilePersistentVolumeClaim,
-		r.reconcileService,
-		r.reconcileConfigMaps,
-		r.reconcileOperandBindInfo,
-		r.reconcileOidcCredentials,
-		r.reconcileDeployment,
-		r.reconcileReporterRoute,
-		r.reconcileUIIngress,
-		r.reconcileIngressProxy,
-		r.reconcileSenderConfiguration,
+	reconcileSteps := []reconcileLRStep{
+		{r.reconcileServiceAccount, "ServiceAccount"},
+		{r.reconcileRole, "Role"},
+		{r.reconcileRoleBinding, "RoleBinding"},
+		{r.reconcileAPISecretToken, "APISecretToken"},
+		{r.reconcileDatabaseSecret, "DatabaseSecret"},
+		{r.reconcilePersistentVolumeClaim, "PersistentVolumeClaim"},
+		{r.reconcileService, "Service"},
+		{r.reconcileCertificate, "Certificate"},
+		{r.reconcileConfigMaps, "ConfigMaps"},
+		{r.reconcileOperandBindInfo, "OperandBindInfo"},
+		{r.reconcileDeployment, "Deployment"},
+		{r.reconcileDatabasePassword, "DatabasePassword"},
+		{r.reconcileReporterRoute, "Route"},
+		{r.reconcileUIIngress, "UIIngress"},
+		{r.reconcileIngressProxy, "IngressProxy"},
+		{r.reconcileSenderConfiguration, "SenderConfiguration"},
 	}
 
 	// Fetch the IBMLicenseServiceReporter instance
@@ -128,16 +207,25 @@ func (r *IBMLicenseServiceReporterReconciler) Reconcile(req reconcile.Request) (
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile req.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Owned objects are automatically garbage collected, additional cleanup is done before removing the finalizer.
 			// Return and don't requeue
-			// reqLogger.Info("IBMLicenseServiceReporter resource not found. Ignoring since object must be deleted")
-			reporter.ClearDefaultSenderConfiguration(r.Client, reqLogger)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the req.
 		return reconcile.Result{}, err
 	}
 
+	if !foundInstance.GetDeletionTimestamp().IsZero() {
+		return r.finalize(foundInstance)
+	}
+	if !controllerutil.ContainsFinalizer(foundInstance, reporter.CleanupFinalizer) {
+		controllerutil.AddFinalizer(foundInstance, reporter.CleanupFinalizer)
+		if err := r.Client.Update(context.TODO(), foundInstance); err != nil {
+			reqLogger.Error(err, "Failed to add finalizer")
+			return reconcile.Result{}, err
+		}
+	}
+
 	instance := foundInstance.DeepCopy()
 
 	err = reporter.UpdateVersion(r.Client, instance)
@@ -147,26 +235,62 @@ func (r *IBMLicenseServiceReporterReconciler) Reconcile(req reconcile.Request) (
 
 	err = instance.Spec.FillDefaultValues(reqLogger, r.Reader)
 	if err != nil {
+		operatorv1alpha1.SetDegradedConditions(&foundInstance.Status.Conditions, instance.GetGeneration(),
+			operatorv1alpha1.ReasonInvalidConfiguration, err.Error())
+		r.updateConditions(foundInstance, instance)
 		return reconcile.Result{}, err
 	}
 
-	r.controllerStatus()
+	r.controllerStatus(r.Capabilities.Get())
 
 	reqLogger.Info("got IBM License Service Reporter application, version=" + instance.Spec.Version)
 
-	for _, reconcileFunction := range reconcileFunctions {
-		recResult, recErr = reconcileFunction.(reconcileLRFunctionType)(instance)
-		if recErr != nil || recResult.Requeue {
+	for _, step := range reconcileSteps {
+		recResult, recErr = step.reconcile(instance)
+		if recErr != nil {
+			operatorv1alpha1.SetDegradedConditions(&foundInstance.Status.Conditions, instance.GetGeneration(),
+				step.component+"ReconcileFailed", recErr.Error())
+			r.updateConditions(foundInstance, instance)
 			return recResult, recErr
 		}
+		// only rotation of the database password waits for the running database and requeues
+		if recResult.Requeue {
+			operatorv1alpha1.SetProgressingConditions(&foundInstance.Status.Conditions, instance.GetGeneration(),
+				"WaitingFor"+step.component, step.component+" is being created or updated")
+			r.updateConditions(foundInstance, instance)
+			return recResult, nil
+		}
 	}
 
 	// Update status logic, using foundInstance, because we do not want to add filled default values to yaml
-	return r.updateStatus(foundInstance)
+	foundInstance.Status.Certificates = instance.Status.Certificates
+	foundInstance.Status.DatabasePassword = instance.Status.DatabasePassword
+	recResult, recErr = r.updateStatus(foundInstance, instance)
+	if recErr == nil {
+		recResult.RequeueAfter = getReporterRequeueDelay(instance, time.Now())
+	}
+	return recResult, recErr
 }
 
+// getReporterRequeueDelay returns time until the earliest renewal of certificate issued by the operator CA or rotation
+// of the database password, which are done by reconciliation
+func getReporterRequeueDelay(instance *operatorv1alpha1.IBMLicenseServiceReporter, now time.Time) time.Duration {
+	delay := res.GetRenewalDelay(instance.Status.Certificates, now)
+	if instance.Status.DatabasePassword != nil {
+		passwordDelay := instance.Status.DatabasePassword.NextRotationTime.Sub(now)
+		if passwordDelay < time.Second {
+			passwordDelay = time.Second
+		}
+		if delay == 0 || passwordDelay < delay {
+			delay = passwordDelay
+		}
+	}
+	return delay
+}
+
+// updateStatus sets pods statuses and conditions of foundInstance, instance with filled default values is used to find operands
 func (r *IBMLicenseServiceReporterReconciler) updateStatus(
-	instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	foundInstance, instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("updateStatus", "entry")
 	podList := &corev1.PodList{}
 	listOpts := []client.ListOption{
@@ -194,69 +318,143 @@ func (r *IBMLicenseServiceReporterReconciler) updateStatus(
 		}
 		podStatuses = append(podStatuses, pod.Status)
 	}
+	foundInstance.Status.LicensingReporterPods = podStatuses
 
-	if !reflect.DeepEqual(podStatuses, instance.Status.LicensingReporterPods) {
-		reqLogger.Info("Updating IBMLicenseServiceReporter status")
-		instance.Status.LicensingReporterPods = podStatuses
-		err := r.Client.Status().Update(context.TODO(), instance)
-		if err != nil {
-			reqLogger.Info("Failed to update pod status")
-		}
+	conditions := &foundInstance.Status.Conditions
+	generation := instance.GetGeneration()
+
+	storageBound, err := r.setStorageCondition(conditions, instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to check PersistentVolumeClaim")
+		return reconcile.Result{}, err
+	}
+	databaseReady := setContainerCondition(conditions, generation, operatorv1alpha1.ConditionDatabaseReady, podList.Items, reporter.DatabaseContainerName)
+	receiverReady := setContainerCondition(conditions, generation, operatorv1alpha1.ConditionReceiverReady, podList.Items, reporter.ReceiverContainerName)
+	uiEnabled, uiReason, uiMessage, err := r.getUIEnablement(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	uiReady := true
+	if uiEnabled {
+		operatorv1alpha1.SetCondition(conditions, generation, operatorv1alpha1.ConditionUIEnabled, metav1.ConditionTrue, uiReason, uiMessage)
+		uiReady = isContainerReady(podList.Items, reporter.UIContainerName)
+	} else {
+		operatorv1alpha1.SetCondition(conditions, generation, operatorv1alpha1.ConditionUIEnabled, metav1.ConditionFalse, uiReason, uiMessage)
 	}
 
+	switch {
+	case !storageBound:
+		operatorv1alpha1.SetProgressingConditions(conditions, generation, "WaitingForStorage", "Waiting for PersistentVolumeClaim to be bound")
+	case !databaseReady:
+		operatorv1alpha1.SetProgressingConditions(conditions, generation, "WaitingForDatabase", "Waiting for database container to become ready")
+	case !receiverReady:
+		operatorv1alpha1.SetProgressingConditions(conditions, generation, "WaitingForReceiver", "Waiting for receiver container to become ready")
+	case !uiReady:
+		operatorv1alpha1.SetProgressingConditions(conditions, generation, "WaitingForUI", "Waiting for UI container to become ready")
+	default:
+		operatorv1alpha1.SetAvailableConditions(conditions, generation, operatorv1alpha1.ReasonAvailable, "License Service Reporter is available")
+	}
+	r.updateConditions(foundInstance, instance)
+
 	reqLogger.Info("reconcile all done")
 	return reconcile.Result{}, nil
 }
 
-func (r *IBMLicenseServiceReporterReconciler) reconcileServiceAccount(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileServiceAccount", "Entry", "instance.GetName()", instance.GetName())
-	expectedSA := reporter.GetServiceAccount(instance)
-	foundSA := &corev1.ServiceAccount{}
-	namespacedName := types.NamespacedName{Name: expectedSA.GetName(), Namespace: expectedSA.GetNamespace()}
-	reconcileResult, err := r.reconcileResourceExistence(instance, expectedSA, foundSA, namespacedName)
-	if err != nil || reconcileResult.Requeue {
-		return reconcileResult, err
+// setStorageCondition sets StorageBound condition and returns true if PersistentVolumeClaim is bound
+func (r *IBMLicenseServiceReporterReconciler) setStorageCondition(conditions *[]metav1.Condition,
+	instance *operatorv1alpha1.IBMLicenseServiceReporter) (bool, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	namespacedName := types.NamespacedName{Name: reporter.PersistenceVolumeClaimName, Namespace: instance.GetNamespace()}
+	if err := r.Client.Get(context.TODO(), namespacedName, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			operatorv1alpha1.SetCondition(conditions, instance.GetGeneration(), operatorv1alpha1.ConditionStorageBound, metav1.ConditionFalse,
+				operatorv1alpha1.ReasonPVCNotFound, reporter.PersistenceVolumeClaimName+" does not exist")
+			return false, nil
+		}
+		return false, err
 	}
-	// Check if found SA has all necessary Pull Secrets
-	shouldUpdate := false
-	for _, imagePullSecret := range expectedSA.ImagePullSecrets {
-		if !res.Contains(foundSA.ImagePullSecrets, imagePullSecret) {
-			foundSA.ImagePullSecrets = append(foundSA.ImagePullSecrets, imagePullSecret)
-			shouldUpdate = true
+	phase := pvc.Status.Phase
+	if phase == "" {
+		phase = corev1.ClaimPending
+	}
+	status := metav1.ConditionFalse
+	if phase == corev1.ClaimBound {
+		status = metav1.ConditionTrue
+	}
+	operatorv1alpha1.SetCondition(conditions, instance.GetGeneration(), operatorv1alpha1.ConditionStorageBound, status,
+		string(phase), "PersistentVolumeClaim "+reporter.PersistenceVolumeClaimName+" with storage class "+instance.Spec.StorageClass+
+			" is "+string(phase))
+	return status == metav1.ConditionTrue, nil
+}
+
+// setContainerCondition sets condition describing readiness of container in reporter pods and returns true if it is ready
+func setContainerCondition(conditions *[]metav1.Condition, generation int64, conditionType string, pods []corev1.Pod,
+	containerName string) bool {
+	if len(pods) == 0 {
+		operatorv1alpha1.SetCondition(conditions, generation, conditionType, metav1.ConditionFalse,
+			operatorv1alpha1.ReasonPodNotFound, "License Service Reporter pod does not exist")
+		return false
+	}
+	if isContainerReady(pods, containerName) {
+		operatorv1alpha1.SetCondition(conditions, generation, conditionType, metav1.ConditionTrue,
+			operatorv1alpha1.ReasonContainerReady, "Container "+containerName+" is ready")
+		return true
+	}
+	message := "Container " + containerName + " is not ready"
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == containerName && containerStatus.State.Waiting != nil {
+				message += ": " + containerStatus.State.Waiting.Reason
+			}
 		}
 	}
-	if shouldUpdate {
-		reqLogger.Info("Updating ServiceAccount", "Updated ServiceAccount", foundSA)
-		err = r.Client.Update(context.TODO(), foundSA)
-		if err != nil {
-			reqLogger.Error(err, "Failed to update ServiceAccount, deleting...")
-			err = r.Client.Delete(context.TODO(), foundSA)
-			if err != nil {
-				reqLogger.Error(err, "Failed to delete ServiceAccount during recreation")
-				return reconcile.Result{}, err
+	operatorv1alpha1.SetCondition(conditions, generation, conditionType, metav1.ConditionFalse,
+		operatorv1alpha1.ReasonContainerNotReady, message)
+	return false
+}
+
+func isContainerReady(pods []corev1.Pod, containerName string) bool {
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == containerName && containerStatus.Ready {
+				return true
 			}
-			reqLogger.Info("Deleted ServiceAccount successfully")
-			return reconcile.Result{Requeue: true, RequeueAfter: time.Minute}, nil
 		}
-		reqLogger.Info("Updated ServiceAccount successfully")
-		// Spec updated - return and do not requeue as it might not consider extra values
-		return reconcile.Result{}, nil
 	}
-	return reconcile.Result{}, nil
+	return false
+}
+
+// updateConditions writes foundInstance status if it differs from the one stored in the cluster
+func (r *IBMLicenseServiceReporterReconciler) updateConditions(foundInstance, instance *operatorv1alpha1.IBMLicenseServiceReporter) {
+	reqLogger := r.Log.WithValues("updateConditions", "entry")
+	foundInstance.Status.ObservedGeneration = instance.GetGeneration()
+	storedInstance := &operatorv1alpha1.IBMLicenseServiceReporter{}
+	namespacedName := types.NamespacedName{Name: foundInstance.GetName(), Namespace: foundInstance.GetNamespace()}
+	if err := r.Client.Get(context.TODO(), namespacedName, storedInstance); err != nil {
+		reqLogger.Info("Failed to get IBMLicenseServiceReporter status")
+		return
+	}
+	if reflect.DeepEqual(storedInstance.Status, foundInstance.Status) {
+		return
+	}
+	reqLogger.Info("Updating IBMLicenseServiceReporter status")
+	storedInstance.Status = foundInstance.Status
+	if err := r.Client.Status().Update(context.TODO(), storedInstance); err != nil {
+		reqLogger.Info("Failed to update IBMLicenseServiceReporter status")
+	}
+}
+
+func (r *IBMLicenseServiceReporterReconciler) reconcileServiceAccount(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	// image pull secrets added to ServiceAccount by others are kept, as they are merged by name
+	return r.applyResource(instance, reporter.GetServiceAccount(instance))
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileRole(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	expectedRole := reporter.GetRole(instance)
-	foundRole := &rbacv1.Role{}
-	namespacedName := types.NamespacedName{Name: expectedRole.GetName(), Namespace: expectedRole.GetNamespace()}
-	return r.reconcileResourceExistence(instance, expectedRole, foundRole, namespacedName)
+	return r.applyResource(instance, reporter.GetRole(instance))
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileRoleBinding(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	expectedRoleBinding := reporter.GetRoleBinding(instance)
-	foundRoleBinding := &rbacv1.RoleBinding{}
-	namespacedName := types.NamespacedName{Name: expectedRoleBinding.GetName(), Namespace: expectedRoleBinding.GetNamespace()}
-	return r.reconcileResourceExistence(instance, expectedRoleBinding, foundRoleBinding, namespacedName)
+	return r.applyResource(instance, reporter.GetRoleBinding(instance))
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcilePersistentVolumeClaim(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
@@ -277,25 +475,129 @@ func (r *IBMLicenseServiceReporterReconciler) reconcileDatabaseSecret(instance *
 	expectedSecret, err := reporter.GetDatabaseSecret(instance)
 	if err != nil {
 		reqLogger.Info("Failed to get expected secret")
-		return reconcile.Result{
-			Requeue:      true,
-			RequeueAfter: time.Minute,
-		}, err
+		return reconcile.Result{}, err
 	}
 	foundSecret := &corev1.Secret{}
 	namespacedName := types.NamespacedName{Name: expectedSecret.GetName(), Namespace: expectedSecret.GetNamespace()}
 	return r.reconcileResourceExistence(instance, expectedSecret, foundSecret, namespacedName)
 }
 
+// reconcileDatabasePassword rotates the database password when the rotation interval passed: the new password is stored
+// in the pending secret, changed in the running database and then written to the database secret, which rolls out
+// the receiver with the new password
+func (r *IBMLicenseServiceReporterReconciler) reconcileDatabasePassword(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("reconcileDatabasePassword", "Entry", "instance.GetName()", instance.GetName())
+	instance.Status.DatabasePassword = nil
+	interval := instance.Spec.GetDatabasePasswordRotationInterval()
+	if interval == 0 {
+		return reconcile.Result{}, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: reporter.DatabaseConfigSecretName, Namespace: instance.GetNamespace()}, secret); err != nil {
+		return reconcile.Result{}, err
+	}
+	if !metav1.IsControlledBy(secret, instance) {
+		reqLogger.Info("Database secret is not created by the operator, its password is not rotated")
+		return reconcile.Result{}, nil
+	}
+
+	// password set when the secret was created is as old as the secret
+	rotatedAt := secret.GetCreationTimestamp().Time
+	if value, found := secret.Annotations[reporter.DatabasePasswordRotatedAtAnnotation]; found {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			rotatedAt = parsed
+		}
+	}
+	pendingSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: reporter.PendingDatabasePasswordSecretName, Namespace: instance.GetNamespace()}, pendingSecret)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if errors.IsNotFound(err) {
+		if time.Now().Before(rotatedAt.Add(interval)) {
+			instance.Status.DatabasePassword = &operatorv1alpha1.DatabasePasswordStatus{
+				LastRotationTime: metav1.Time{Time: rotatedAt},
+				NextRotationTime: metav1.Time{Time: rotatedAt.Add(interval)},
+			}
+			return reconcile.Result{}, nil
+		}
+		password, err := reporter.GetDatabasePassword(instance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		pendingSecret = reporter.GetPendingDatabasePasswordSecret(instance, password)
+		if err := controllerutil.SetControllerReference(instance, pendingSecret, r.Scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+		// the new password is stored before it is set in the database, so that it is not lost after a failure
+		if err := r.Client.Create(context.TODO(), pendingSecret); err != nil {
+			return reconcile.Result{}, err
+		}
+		pendingSecret.Data = map[string][]byte{reporter.PostgresPasswordKey: []byte(password)}
+	}
+	password := string(pendingSecret.Data[reporter.PostgresPasswordKey])
+
+	pod, err := r.getDatabasePod(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if pod == "" {
+		reqLogger.Info("Waiting for running database to change its password")
+		return reconcile.Result{Requeue: true}, nil
+	}
+	if _, err := r.Executor.Exec(instance.GetNamespace(), pod, reporter.DatabaseContainerName, reporter.GetPsqlCommand(),
+		strings.NewReader(reporter.GetChangePasswordStatement(password))); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// receiver is rolled out with the new password, as content hash of the secret changes in its pod template
+	rotatedAt = time.Now().Truncate(time.Second)
+	secret.Data[reporter.PostgresPasswordKey] = []byte(password)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[reporter.DatabasePasswordRotatedAtAnnotation] = rotatedAt.UTC().Format(time.RFC3339)
+	if err := r.Client.Update(context.TODO(), secret); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.Client.Delete(context.TODO(), pendingSecret); err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "DatabasePasswordRotated",
+		"Database password was changed, License Service Reporter is restarted with the new password")
+	instance.Status.DatabasePassword = &operatorv1alpha1.DatabasePasswordStatus{
+		LastRotationTime: metav1.Time{Time: rotatedAt},
+		NextRotationTime: metav1.Time{Time: rotatedAt.Add(interval)},
+	}
+	return reconcile.Result{}, nil
+}
+
+// getDatabasePod returns name of License Service Reporter pod with ready database container, empty when there is none
+func (r *IBMLicenseServiceReporterReconciler) getDatabasePod(instance *operatorv1alpha1.IBMLicenseServiceReporter) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, client.InNamespace(instance.GetNamespace()),
+		client.MatchingLabels(reporter.LabelsForSelector(instance))); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == reporter.DatabaseContainerName && containerStatus.Ready {
+				return pod.GetName(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
 func (r *IBMLicenseServiceReporterReconciler) reconcileAPISecretToken(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("reconcileAPISecretToken", "Entry", "instance.GetName()", instance.GetName())
 	expectedSecret, err := reporter.GetAPISecretToken(instance)
 	if err != nil {
 		reqLogger.Info("Failed to get expected secret")
-		return reconcile.Result{
-			Requeue:      true,
-			RequeueAfter: time.Minute,
-		}, err
+		return reconcile.Result{}, err
 	}
 	foundSecret := &corev1.Secret{}
 	namespacedName := types.NamespacedName{Name: expectedSecret.GetName(), Namespace: expectedSecret.GetNamespace()}
@@ -303,134 +605,189 @@ func (r *IBMLicenseServiceReporterReconciler) reconcileAPISecretToken(instance *
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileService(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileService", "Entry", "instance.GetName()", instance.GetName())
-	expectedService := reporter.GetService(instance)
-	foundService := &corev1.Service{}
-	namespacedName := types.NamespacedName{Name: expectedService.GetName(), Namespace: expectedService.GetNamespace()}
-	reconcileResult, err := r.reconcileResourceExistence(instance, expectedService, foundService, namespacedName)
-	if err != nil || reconcileResult.Requeue {
-		return reconcileResult, err
+	return r.applyResource(instance, reporter.GetService(instance, r.Capabilities.Get()))
+}
+
+// reconcileCertificate applies cert-manager Certificate of License Service Reporter, when cert-manager issues its certificate,
+// or secret with certificate issued by the operator CA
+func (r *IBMLicenseServiceReporterReconciler) reconcileCertificate(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	instance.Status.Certificates = nil
+	if instance.Spec.HTTPSCertsSource == operatorv1alpha1.OperatorCACertsSource {
+		return r.reconcileOperatorCACertificate(instance)
+	}
+	if instance.Spec.HTTPSCertsSource != operatorv1alpha1.CertManagerCertsSource {
+		return reconcile.Result{}, nil
+	}
+	if err := res.CheckCertManager(r.Capabilities.Get(), instance.Spec.CertManagerIssuer); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.applyResource(instance, reporter.GetCertificate(instance))
+}
+
+// reconcileOperatorCACertificate applies secret with certificate issued by the operator CA and publishes the CA bundle,
+// expiry of the certificates is recorded in the status
+func (r *IBMLicenseServiceReporterReconciler) reconcileOperatorCACertificate(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("reconcileOperatorCACertificate", "Entry", "instance.GetName()", instance.GetName())
+	now := time.Now()
+	ca, err := res.GetOperatorCA(r.Reader, r.Client, instance.GetNamespace(), now)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result, err := res.ApplyResource(&reqLogger, r.Client, r.Scheme, res.GetCABundleConfigMap(instance.GetNamespace(), ca.Bundle)); err != nil {
+		return result, err
+	}
+	secret, status, err := reporter.GetCertificateSecret(r.Reader, instance, ca, now)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result, err := r.applyResource(instance, secret); err != nil || result.Requeue {
+		return result, err
 	}
-	return res.UpdateServiceIfNeeded(&reqLogger, r.Client, expectedService, foundService)
+	instance.Status.Certificates = []operatorv1alpha1.CertificateStatus{ca.Status, status}
+	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileConfigMaps(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileConfigMaps", "Entry", "instance.GetName()", instance.GetName())
 	expectedCMs := []*corev1.ConfigMap{
 		reporter.GetZenConfigMap(instance),
 	}
 	for _, expectedCM := range expectedCMs {
-		foundCM := &corev1.ConfigMap{}
-		namespacedName := types.NamespacedName{Name: expectedCM.GetName(), Namespace: expectedCM.GetNamespace()}
-		reconcileResult, err := r.reconcileResourceExistence(instance, expectedCM, foundCM, namespacedName)
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
+		if result, err := r.applyResource(instance, expectedCM); err != nil || result.Requeue {
+			return result, err
 		}
-		if !res.CompareConfigMap(foundCM, expectedCM) {
-			if updateReconcileResult, err := res.UpdateResource(&reqLogger, r.Client, expectedCM, foundCM); err != nil || updateReconcileResult.Requeue {
-				return updateReconcileResult, err
-			}
-		}
-
 	}
 	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileOperandBindInfo(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-
-	if res.IsODLM {
-		reqLogger := r.Log.WithValues("reconcileService", "Entry", "instance.GetName()", instance.GetName())
-		expectedBindInfo := reporter.GetBindInfo(instance)
-		foundBindInfo := &odlm.OperandBindInfo{}
-		namespacedName := types.NamespacedName{Name: expectedBindInfo.GetName(), Namespace: expectedBindInfo.GetNamespace()}
-		reconcileResult, err := r.reconcileResourceExistence(instance, expectedBindInfo, foundBindInfo, namespacedName)
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
-		}
-		return reporter.UpdateOperandBindInfoIfNeeded(&reqLogger, r.Client, expectedBindInfo, foundBindInfo)
+	if r.Capabilities.Get().ODLM {
+		return r.applyResource(instance, reporter.GetBindInfo(instance))
 	}
 	return reconcile.Result{}, nil
 }
 
-func (r *IBMLicenseServiceReporterReconciler) reconcileOidcCredentials(
-	instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileOidcCredentials", "Entry", "instance.GetName()", instance.GetName())
+// getUIEnablement decides whether UI container is deployed according to spec.ui.enabled and platform OIDC credentials
+// secret, reason and message describe the decision in UIEnabled condition
+func (r *IBMLicenseServiceReporterReconciler) getUIEnablement(
+	instance *operatorv1alpha1.IBMLicenseServiceReporter) (bool, string, string, error) {
 	foundSecret := &corev1.Secret{}
 	namespacedName := types.NamespacedName{Name: res.UIPlatformSecretName, Namespace: instance.GetNamespace()}
-	err := r.Client.Get(context.TODO(), namespacedName, foundSecret)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			reqLogger.Info(res.UIPlatformSecretName + " secret does not exist => Reporter should exist without UI container")
-			res.IsUIEnabled = false
-			return reconcile.Result{}, nil
+	secretFound := true
+	if err := r.Client.Get(context.TODO(), namespacedName, foundSecret); err != nil {
+		if !errors.IsNotFound(err) {
+			r.Log.Error(err, "Failed to get "+res.UIPlatformSecretName+" secret")
+			return false, "", "", err
 		}
-		reqLogger.Error(err, "Failed to get "+res.UIPlatformSecretName+" secret")
-		return reconcile.Result{}, err
+		secretFound = false
 	}
-	reqLogger.Info(res.UIPlatformSecretName + " secret does exist => Reporter should exist with UI container")
-	res.IsUIEnabled = true
-	return reconcile.Result{}, nil
+
+	switch instance.Spec.GetUIEnabledMode() {
+	case operatorv1alpha1.UIEnabledFalse:
+		return false, operatorv1alpha1.ReasonUIDisabledInSpec, "spec.ui.enabled is false, UI container is skipped", nil
+	case operatorv1alpha1.UIEnabledTrue:
+		if !secretFound {
+			return true, operatorv1alpha1.ReasonUIEnabledInSpec, "spec.ui.enabled is true, UI container is deployed, but it can not start until " +
+				res.UIPlatformSecretName + " secret is created in " + instance.GetNamespace() + " namespace", nil
+		}
+		return true, operatorv1alpha1.ReasonUIEnabledInSpec, "spec.ui.enabled is true, UI container is deployed", nil
+	}
+	if secretFound {
+		return true, operatorv1alpha1.ReasonOidcCredentialsFound, res.UIPlatformSecretName + " secret found, UI container is deployed", nil
+	}
+	return false, operatorv1alpha1.ReasonOidcCredentialsMissing, res.UIPlatformSecretName + " secret not found in " +
+		instance.GetNamespace() + " namespace, UI container is skipped", nil
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("reconcileDeployment", "Entry", "instance.GetName()", instance.GetName())
-	expectedDeployment := reporter.GetDeployment(instance)
-	foundDeployment := &appsv1.Deployment{}
-	namespacedName := types.NamespacedName{Name: expectedDeployment.GetName(), Namespace: expectedDeployment.GetNamespace()}
-	reconcileResult, err := r.reconcileResourceExistence(instance, expectedDeployment, foundDeployment, namespacedName)
-	if err != nil || reconcileResult.Requeue {
-		return reconcileResult, err
+	uiEnabled, _, uiMessage, err := r.getUIEnablement(instance)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
-
-	shouldUpdate := res.ShouldUpdateDeployment(
-		&reqLogger,
-		&expectedDeployment.Spec.Template,
-		&foundDeployment.Spec.Template,
-	)
-
-	if shouldUpdate {
-		return res.UpdateResource(&reqLogger, r.Client, expectedDeployment, foundDeployment)
+	reqLogger.Info(uiMessage)
+	capabilities := r.Capabilities.Get()
+	configHashes, err := res.GetConfigHashes(r.Client, instance.GetNamespace(), reporter.GetConfigReferences(instance, capabilities, uiEnabled))
+	if err != nil {
+		return reconcile.Result{}, err
 	}
-
-	return reconcile.Result{}, nil
+	return r.applyResource(instance, reporter.GetDeployment(instance, capabilities, uiEnabled, configHashes))
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileReporterRoute(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	if res.IsRouteAPI {
-		expectedRoute := reporter.GetReporterRoute(instance)
-		foundRoute := &routev1.Route{}
-		namespacedName := types.NamespacedName{Name: expectedRoute.GetName(), Namespace: expectedRoute.GetNamespace()}
-		reconcileResult, err := r.reconcileResourceExistence(instance, expectedRoute, foundRoute, namespacedName)
-
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
-		}
-		reqLogger := r.Log.WithValues("reconcileRoute", "Entry", "instance.GetName()", instance.GetName())
-
-		if !res.CompareRoutes(reqLogger, expectedRoute, foundRoute) {
-			return res.UpdateResource(&reqLogger, r.Client, expectedRoute, foundRoute)
-		}
+	if r.Capabilities.Get().RouteAPI {
+		return r.applyResource(instance, reporter.GetReporterRoute(instance))
 	}
 	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileUIIngress(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	expectedIngress := reporter.GetUIIngress(instance)
-	foundIngress := &networkingv1.Ingress{}
-	namespacedName := types.NamespacedName{Name: expectedIngress.GetName(), Namespace: expectedIngress.GetNamespace()}
-	return r.reconcileResourceExistence(instance, expectedIngress, foundIngress, namespacedName)
+	return r.applyResource(instance, reporter.GetUIIngress(instance))
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileIngressProxy(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	expectedIngress := reporter.GetUIIngressProxy(instance)
-	foundIngress := &networkingv1.Ingress{}
-	namespacedName := types.NamespacedName{Name: expectedIngress.GetName(), Namespace: expectedIngress.GetNamespace()}
-	return r.reconcileResourceExistence(instance, expectedIngress, foundIngress, namespacedName)
+	return r.applyResource(instance, reporter.GetUIIngressProxy(instance))
 }
 
-//goland:noinspection GoUnusedParameter
 func (r *IBMLicenseServiceReporterReconciler) reconcileSenderConfiguration(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
-	return reconcile.Result{}, reporter.AddSenderConfiguration(r.Client, r.Log)
+	return reconcile.Result{}, reporter.AddSenderConfiguration(r.Client, instance, r.Log)
+}
+
+// finalize exports aggregated data if requested and removes sender configuration, which is not garbage collected with
+// the instance, from IBMLicensing instances configured by it, then removes the finalizer, failed steps are retried
+func (r *IBMLicenseServiceReporterReconciler) finalize(instance *operatorv1alpha1.IBMLicenseServiceReporter) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(instance, reporter.CleanupFinalizer) {
+		return reconcile.Result{}, nil
+	}
+	reqLogger := r.Log.WithValues("finalize", "Entry", "instance.GetName()", instance.GetName())
+
+	if instance.Spec.IsExportOnDelete() {
+		if err := r.exportAggregatedData(instance); err != nil {
+			reqLogger.Error(err, "Failed to export aggregated data")
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "FinalExportFailed",
+				"Export of aggregated data failed, deletion is retried: "+err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := reporter.ClearDefaultSenderConfiguration(r.Client, instance, reqLogger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(instance, reporter.CleanupFinalizer)
+	if err := r.Client.Update(context.TODO(), instance); err != nil {
+		reqLogger.Error(err, "Failed to remove finalizer")
+		return reconcile.Result{}, err
+	}
+	reqLogger.Info("Cleanup done, finalizer removed")
+	return reconcile.Result{}, nil
+}
+
+// exportAggregatedData stores data exported from receiver in a secret, which is not owned by the instance
+func (r *IBMLicenseServiceReporterReconciler) exportAggregatedData(instance *operatorv1alpha1.IBMLicenseServiceReporter) error {
+	reqLogger := r.Log.WithValues("exportAggregatedData", "Entry", "instance.GetName()", instance.GetName())
+	data, err := reporter.ExportAggregatedData(r.Client, instance)
+	if err != nil {
+		return err
+	}
+	exportSecret := reporter.GetFinalExportSecret(instance, data)
+	if _, err := res.ApplyResource(&reqLogger, r.Client, r.Scheme, exportSecret); err != nil {
+		return err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "FinalExportStored",
+		"Aggregated data exported to secret "+exportSecret.GetNamespace()+"/"+exportSecret.GetName())
+	return nil
+}
+
+// applyResource sets controller of expected resource and applies it with server-side apply
+func (r *IBMLicenseServiceReporterReconciler) applyResource(
+	instance *operatorv1alpha1.IBMLicenseServiceReporter, expectedRes res.ResourceObject) (reconcile.Result, error) {
+
+	reqLogger := r.Log.WithValues(reflect.TypeOf(expectedRes).String(), "Entry", "instance.GetName()", instance.GetName())
+	if err := controllerutil.SetControllerReference(instance, expectedRes, r.Scheme); err != nil {
+		reqLogger.Error(err, "Failed to define expected resource")
+		return reconcile.Result{}, err
+	}
+	return res.ApplyResource(&reqLogger, r.Client, r.Scheme, expectedRes)
 }
 
 func (r *IBMLicenseServiceReporterReconciler) reconcileResourceExistence(
@@ -463,9 +820,8 @@ func (r *IBMLicenseServiceReporterReconciler) reconcileResourceExistence(
 					return reconcile.Result{}, err
 				}
 			}
-			// Created successfully, or already exists - return and requeue
-			time.Sleep(time.Second * 5)
-			return reconcile.Result{Requeue: true, RequeueAfter: time.Second}, nil
+			// Created successfully, or already exists - following resources are reconciled in the same pass
+			return reconcile.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed to get "+resType.String(), "Name", expectedRes.GetName(),
 			"Namespace", expectedRes.GetNamespace())
@@ -475,18 +831,18 @@ func (r *IBMLicenseServiceReporterReconciler) reconcileResourceExistence(
 	return reconcile.Result{}, nil
 }
 
-func (r *IBMLicenseServiceReporterReconciler) controllerStatus() {
-	if res.IsRouteAPI {
+func (r *IBMLicenseServiceReporterReconciler) controllerStatus(capabilities res.Capabilities) {
+	if capabilities.RouteAPI {
 		r.Log.Info("Route feature is enabled")
 	} else {
 		r.Log.Info("Route feature is disabled")
 	}
-	if res.IsServiceCAAPI {
+	if capabilities.ServiceCAAPI {
 		r.Log.Info("ServiceCA feature is enabled")
 	} else {
 		r.Log.Info("ServiceCA feature is disabled")
 	}
-	if res.IsODLM {
+	if capabilities.ODLM {
 		r.Log.Info("ODLM is available")
 	} else {
 		r.Log.Info("ODLM is unavailable")
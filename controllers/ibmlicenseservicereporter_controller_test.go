@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -97,6 +98,14 @@ var _ = Describe("IBMLicenseServiceReporter controller", func() {
 				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, newInstance)).Should(Succeed())
 				return newInstance.Status.LicensingReporterPods[0].Phase
 			}, timeout, interval).Should(Equal(v1.PodRunning))
+
+			By("Checking database and receiver conditions of the IBMLicenseServiceReporter")
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, newInstance)).Should(Succeed())
+				return meta.IsStatusConditionTrue(newInstance.Status.Conditions, operatorv1alpha1.ConditionDatabaseReady) &&
+					meta.IsStatusConditionTrue(newInstance.Status.Conditions, operatorv1alpha1.ConditionReceiverReady) &&
+					newInstance.Status.ObservedGeneration == newInstance.Generation
+			}, timeout, interval).Should(BeTrue())
 		})
 	})
 })
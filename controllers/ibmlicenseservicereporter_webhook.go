@@ -0,0 +1,168 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// IBMLicenseServiceReporterValidatingWebhookPath is the path on which IBMLicenseServiceReporter validating webhook is served
+const IBMLicenseServiceReporterValidatingWebhookPath = "/validate-operator-ibm-com-v1alpha1-ibmlicenseservicereporter"
+
+// IBMLicenseServiceReporterDefaultingWebhookPath is the path on which IBMLicenseServiceReporter defaulting webhook is served
+const IBMLicenseServiceReporterDefaultingWebhookPath = "/mutate-operator-ibm-com-v1alpha1-ibmlicenseservicereporter"
+
+// +kubebuilder:webhook:path=/validate-operator-ibm-com-v1alpha1-ibmlicenseservicereporter,mutating=false,failurePolicy=fail,groups=operator.ibm.com,resources=ibmlicenseservicereporters,verbs=create;update,versions=v1alpha1,name=vibmlicenseservicereporter.operator.ibm.com,sideEffects=None
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+
+// IBMLicenseServiceReporterValidator rejects invalid IBMLicenseServiceReporter configurations and warns about ones
+// that can not work yet
+type IBMLicenseServiceReporterValidator struct {
+	Client       client.Client
+	Reader       client.Reader
+	Log          logr.Logger
+	Capabilities *CapabilityDetector
+	decoder      *admission.Decoder
+}
+
+// blank assignment to verify that IBMLicenseServiceReporterValidator implements admission.Handler
+var _ admission.Handler = &IBMLicenseServiceReporterValidator{}
+
+// Handle validates IBMLicenseServiceReporter on create and update
+func (v *IBMLicenseServiceReporterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	instance := &operatorv1alpha1.IBMLicenseServiceReporter{}
+	if err := v.decoder.Decode(req, instance); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	validation := &webhookValidation{}
+
+	if req.Operation == admissionv1beta1.Create {
+		instances := &operatorv1alpha1.IBMLicenseServiceReporterList{}
+		if err := v.Client.List(ctx, instances); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, existing := range instances.Items {
+			if existing.GetName() != instance.GetName() || existing.GetNamespace() != req.Namespace {
+				validation.deny("only one IBMLicenseServiceReporter instance is supported, IBMLicenseServiceReporter " +
+					existing.GetNamespace() + "/" + existing.GetName() + " already exists")
+			}
+		}
+	}
+
+	spec := &instance.Spec
+	validation.validateContainer("spec.receiverContainer", spec.ReceiverContainer)
+	validation.validateContainer("spec.reporterUIContainer", spec.ReporterUIContainer)
+	validation.validateContainer("spec.databaseContainer", spec.DatabaseContainer)
+	if spec.GetUIEnabledMode() == operatorv1alpha1.UIEnabledTrue {
+		if err := validation.warnIfSecretMissing(ctx, v.Client, res.UIPlatformSecretName, req.Namespace,
+			"spec.ui.enabled is true"); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	if !v.Capabilities.Get().RouteAPI {
+		validation.warn("Route API is not available on this cluster, License Service Reporter will not be exposed with Route")
+	}
+	switch spec.HTTPSCertsSource {
+	case "", operatorv1alpha1.OcpCertsSource, operatorv1alpha1.OperatorCACertsSource:
+	case operatorv1alpha1.CertManagerCertsSource:
+		validation.validateCertManager(v.Capabilities.Get(), spec.CertManagerIssuer)
+	default:
+		validation.warn("spec.httpsCertsSource " + string(spec.HTTPSCertsSource) + " is not supported by License Service Reporter, " +
+			"certificate from OpenShift service CA is used")
+	}
+
+	if spec.DatabasePassword != nil {
+		validation.validateDatabasePassword(spec.DatabasePassword)
+	}
+
+	if spec.StorageClass != "" {
+		storageClass := &storagev1.StorageClass{}
+		err := v.Reader.Get(ctx, types.NamespacedName{Name: spec.StorageClass}, storageClass)
+		if apierrors.IsNotFound(err) {
+			validation.warn("storage class " + spec.StorageClass + " from spec.storageClass does not exist")
+		} else if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	} else if _, err := operatorv1alpha1.GetDefaultStorageClass(v.Log, v.Reader); err != nil {
+		validation.warn("spec.storageClass is not set and default storage class can not be used: " + err.Error())
+	}
+
+	return validation.response()
+}
+
+// InjectDecoder injects the decoder into IBMLicenseServiceReporterValidator
+func (v *IBMLicenseServiceReporterValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:path=/mutate-operator-ibm-com-v1alpha1-ibmlicenseservicereporter,mutating=true,failurePolicy=ignore,groups=operator.ibm.com,resources=ibmlicenseservicereporters,verbs=create;update,versions=v1alpha1,name=mibmlicenseservicereporter.operator.ibm.com,sideEffects=None
+
+// IBMLicenseServiceReporterDefaulter persists default values of IBMLicenseServiceReporter, so that deployed configuration
+// is visible in the instance
+type IBMLicenseServiceReporterDefaulter struct {
+	Reader  client.Reader
+	Log     logr.Logger
+	decoder *admission.Decoder
+}
+
+// blank assignment to verify that IBMLicenseServiceReporterDefaulter implements admission.Handler
+var _ admission.Handler = &IBMLicenseServiceReporterDefaulter{}
+
+// Handle fills default values of IBMLicenseServiceReporter according to defaults policy annotation
+func (d *IBMLicenseServiceReporterDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	instance := &operatorv1alpha1.IBMLicenseServiceReporter{}
+	if err := d.decoder.Decode(req, instance); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := instance.Spec.FillPersistentDefaultValues(operatorv1alpha1.IsPinningDefaults(instance), d.Log, d.Reader); err != nil {
+		d.Log.Error(err, "Failed to fill default values of IBMLicenseServiceReporter")
+		return defaultsNotPersistedResponse(err)
+	}
+	return patchResponse(req, instance)
+}
+
+// InjectDecoder injects the decoder into IBMLicenseServiceReporterDefaulter
+func (d *IBMLicenseServiceReporterDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// validateDatabasePassword checks that database passwords can be generated and are rotated with positive interval
+func (validation *webhookValidation) validateDatabasePassword(policy *operatorv1alpha1.IBMLicenseServiceReporterDatabasePassword) {
+	if policy.RotationInterval != nil && policy.RotationInterval.Duration <= 0 {
+		validation.deny("spec.databasePassword.rotationInterval must be positive")
+	}
+	if policy.Charset == "" {
+		return
+	}
+	if err := operatorv1alpha1.ValidatePasswordCharset(policy.Charset); err != nil {
+		validation.deny(err.Error())
+	}
+}
@@ -27,8 +27,9 @@ import (
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/controllers/resources/service"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
 	routev1 "github.com/openshift/api/route/v1"
-	rhmp "github.com/redhat-marketplace/redhat-marketplace-operator/v2/apis/marketplace/v1beta1"
+	meterdefv1beta1 "github.com/redhat-marketplace/redhat-marketplace-operator/v2/apis/marketplace/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -36,31 +37,140 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 type reconcileLSFunctionType = func(*operatorv1alpha1.IBMLicensing) (reconcile.Result, error)
 
+// reconcileLSStep is a single step of IBMLicensing reconciliation, component is used in status condition reasons
+type reconcileLSStep struct {
+	reconcile reconcileLSFunctionType
+	component string
+}
+
 func (r *IBMLicensingReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := res.UpdateCacheClusterExtensions(mgr.GetAPIReader()); err != nil {
-		r.Log.Error(err, "Error during checking K8s API")
-	}
+	capabilities := r.Capabilities.Get()
+
+	// resources controlled by prometheus service are mapped to IBMLicensing, which uses the service namespace
+	prometheusServiceOwnedHandler := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapPrometheusServiceOwned)}
 
 	watcher := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.Options.controllerOptions()).
 		For(&operatorv1alpha1.IBMLicensing{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, prometheusServiceOwnedHandler).
+		Watches(&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapReferencedSecret)}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapReferencedConfig)}).
+		Watches(&source.Channel{Source: r.Capabilities.Subscribe()},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapAllInstances)})
 
-	if res.IsRouteAPI {
-		watcher.Owns(&operatorv1alpha1.IBMLicenseServiceReporter{})
+	// watches are set up for APIs available when the operator starts
+	if capabilities.RouteAPI {
+		watcher.Owns(&routev1.Route{})
+	}
+	if capabilities.ServiceMonitorAPI {
+		watcher.Watches(&source.Kind{Type: &monitoringv1.ServiceMonitor{}}, prometheusServiceOwnedHandler)
+	}
+	if capabilities.MeterDefinitionAPI {
+		watcher.Watches(&source.Kind{Type: &meterdefv1beta1.MeterDefinition{}}, prometheusServiceOwnedHandler)
+	}
+	if capabilities.CertManagerAPI {
+		watcher.Owns(res.NewCertificate())
 	}
 
 	return watcher.Complete(r)
 }
 
+// mapPrometheusServiceOwned maps resources controlled by prometheus service to IBMLicensing instances deployed in their namespace
+func (r *IBMLicensingReconciler) mapPrometheusServiceOwned(object handler.MapObject) []reconcile.Request {
+	controllerRef := metav1.GetControllerOf(object.Meta)
+	if controllerRef == nil || controllerRef.Kind != "Service" || controllerRef.Name != service.GetPrometheusServiceName() {
+		return nil
+	}
+	return r.mapInstances(func(instance *operatorv1alpha1.IBMLicensing) bool {
+		return r.getInstanceNamespace(instance) == object.Meta.GetNamespace()
+	})
+}
+
+// mapReferencedSecret maps secrets, which are used but not created by the operator or are used by License Service pods,
+// to IBMLicensing instances referencing them
+func (r *IBMLicensingReconciler) mapReferencedSecret(object handler.MapObject) []reconcile.Request {
+	return r.mapInstances(func(instance *operatorv1alpha1.IBMLicensing) bool {
+		if r.getInstanceNamespace(instance) != object.Meta.GetNamespace() {
+			return false
+		}
+		name := object.Meta.GetName()
+		if instance.Spec.HTTPSCertsSource == operatorv1alpha1.CustomCertsSource && name == service.LicenseServiceCustomCertName {
+			return true
+		}
+		if instance.Spec.Sender != nil && name == instance.Spec.Sender.ReporterSecretToken {
+			return true
+		}
+		return r.isConfigReferenced(instance, object)
+	})
+}
+
+// mapReferencedConfig maps configmaps used by License Service pods to IBMLicensing instances controlling the pods,
+// so that pods are rolled out when their content changes
+func (r *IBMLicensingReconciler) mapReferencedConfig(object handler.MapObject) []reconcile.Request {
+	return r.mapInstances(func(instance *operatorv1alpha1.IBMLicensing) bool {
+		return r.getInstanceNamespace(instance) == object.Meta.GetNamespace() && r.isConfigReferenced(instance, object)
+	})
+}
+
+// isConfigReferenced returns true when the secret or configmap is used by License Service deployment of the instance
+func (r *IBMLicensingReconciler) isConfigReferenced(instance *operatorv1alpha1.IBMLicensing, object handler.MapObject) bool {
+	deployment := &appsv1.Deployment{}
+	namespacedName := types.NamespacedName{Name: service.GetResourceName(instance), Namespace: object.Meta.GetNamespace()}
+	if err := r.Client.Get(context.TODO(), namespacedName, deployment); err != nil {
+		return false
+	}
+	return res.GetConfigReferences(deployment.Spec.Template.Spec).Contains(object.Object, object.Meta.GetName())
+}
+
+// mapAllInstances maps an event, f.e. change of cluster capabilities, to all IBMLicensing instances
+func (r *IBMLicensingReconciler) mapAllInstances(handler.MapObject) []reconcile.Request {
+	return r.mapInstances(func(*operatorv1alpha1.IBMLicensing) bool {
+		return true
+	})
+}
+
+// mapInstances returns requests for IBMLicensing instances accepted by the filter
+func (r *IBMLicensingReconciler) mapInstances(filter func(*operatorv1alpha1.IBMLicensing) bool) []reconcile.Request {
+	instances := &operatorv1alpha1.IBMLicensingList{}
+	if err := r.Client.List(context.TODO(), instances); err != nil {
+		r.Log.Error(err, "Failed to list IBMLicensing instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range instances.Items {
+		if filter(&instances.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instances.Items[i].GetName()}})
+		}
+	}
+	return requests
+}
+
+// getInstanceNamespace returns namespace of License Service, which defaults to the operator namespace
+func (r *IBMLicensingReconciler) getInstanceNamespace(instance *operatorv1alpha1.IBMLicensing) string {
+	if instance.Spec.InstanceNamespace == "" {
+		return r.OperatorNamespace
+	}
+	return instance.Spec.InstanceNamespace
+}
+
 // blank assignment to verify that IBMLicensingReconciler implements reconcile.Reconciler
 var _ reconcile.Reconciler = &IBMLicensingReconciler{}
 
@@ -72,7 +182,10 @@ type IBMLicensingReconciler struct {
 	client.Reader
 	Log               logr.Logger
 	Scheme            *runtime.Scheme
+	Recorder          record.EventRecorder
 	OperatorNamespace string
+	Options           ReconcileOptions
+	Capabilities      *CapabilityDetector
 }
 
 // //kubebuilder:rbac:namespace=ibm-common-services,groups=,resources=pod,verbs=get;list;watch;create;update;patch;delete
@@ -85,14 +198,15 @@ type IBMLicensingReconciler struct {
 
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=operator.ibm.com,resources=ibmlicensings;ibmlicensings/status;ibmlicensings/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="apps",resources=deployments/finalizers,verbs=update
-// +kubebuilder:rbac:namespace=ibm-common-services,groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;watch;list;delete
+// +kubebuilder:rbac:namespace=ibm-common-services,groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create;watch;list;delete;update;patch
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="",resources=pods,verbs=get
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="",resources=pods,verbs=get
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=apps,resources=replicasets;deployments,verbs=get
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="",resources=pods;nodes;namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:namespace=ibm-common-services,groups=marketplace.redhat.com,resources=meterdefinitions,verbs=get;list;create;update;watch
+// +kubebuilder:rbac:namespace=ibm-common-services,groups=marketplace.redhat.com,resources=meterdefinitions,verbs=get;list;create;update;patch;watch
+// +kubebuilder:rbac:namespace=ibm-common-services,groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=networking.k8s.io;extensions,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:namespace=ibm-common-services,groups="",resources=pods;services;services/finalizers;endpoints;persistentvolumeclaims;events;configmaps;secrets;namespaces;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
@@ -104,10 +218,6 @@ func (r *IBMLicensingReconciler) Reconcile(req reconcile.Request) (reconcile.Res
 	reqLogger := r.Log.WithValues("ibmlicensing", req.NamespacedName)
 	reqLogger.Info("Reconciling IBMLicensing")
 
-	if err := res.UpdateCacheClusterExtensions(r.Reader); err != nil {
-		reqLogger.Error(err, "Error during checking K8s API")
-	}
-
 	// Fetch the IBMLicensing instance
 	foundInstance := &operatorv1alpha1.IBMLicensing{}
 	err := r.Client.Get(context.TODO(), req.NamespacedName, foundInstance)
@@ -123,6 +233,13 @@ func (r *IBMLicensingReconciler) Reconcile(req reconcile.Request) (reconcile.Res
 		// reqLogger.Error(err, "Failed to get IBMLicensing")
 		return reconcile.Result{}, err
 	}
+	if !foundInstance.GetDeletionTimestamp().IsZero() {
+		return r.finalize(foundInstance)
+	}
+	if err := r.reconcileFinalizer(foundInstance); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	instance := foundInstance.DeepCopy()
 
 	err = service.UpdateVersion(r.Client, instance)
@@ -130,43 +247,73 @@ func (r *IBMLicensingReconciler) Reconcile(req reconcile.Request) (reconcile.Res
 		reqLogger.Error(err, "Can not update version in CR")
 	}
 
-	err = instance.Spec.FillDefaultValues(res.IsServiceCAAPI, res.IsRouteAPI, res.RHMPEnabled, r.OperatorNamespace)
+	capabilities := r.Capabilities.Get()
+	err = instance.Spec.FillDefaultValues(capabilities.ServiceCAAPI, capabilities.RouteAPI, res.DefaultRHMPEnabled, r.OperatorNamespace)
 	if err != nil {
+		operatorv1alpha1.SetDegradedConditions(&foundInstance.Status.Conditions, instance.GetGeneration(),
+			operatorv1alpha1.ReasonInvalidConfiguration, err.Error())
+		r.updateConditions(foundInstance, instance, reqLogger)
 		return reconcile.Result{}, err
 	}
-	r.controllerStatus(instance)
+	r.controllerStatus(instance, capabilities)
 
 	reqLogger.Info("got IBM License Service application, version=" + instance.Spec.Version)
 
 	var recResult reconcile.Result
 
-	reconcileFunctions := []interface{}{
-		r.reconcileAPISecretToken,
-		r.reconcileUploadToken,
-		r.reconcileConfigMaps,
-		r.reconcileServices,
-		r.reconcileDeployment,
-		r.reconcileIngress,
-		r.reconcileRoute,
-		r.reconcileMeterDefinition,
+	// rotation status is recorded again by reconciliation of each token secret
+	instance.Status.TokenRotation = nil
+	reconcileSteps := []reconcileLSStep{
+		{r.reconcileAPISecretToken, "APISecretToken"},
+		{r.reconcileUploadToken, "UploadToken"},
+		{r.reconcileConfigMaps, "ConfigMaps"},
+		{r.reconcileServices, "Service"},
+		{r.reconcileCertificates, "Certificates"},
+		{r.reconcileDeployment, "Deployment"},
+		{r.reconcileIngress, "Ingress"},
+		{r.reconcileRoute, "Route"},
+		{r.reconcileMeterDefinition, "MeterDefinition"},
 	}
 
 	if instance.Spec.IsRHMPEnabled() {
-		reconcileFunctions = append(reconcileFunctions, r.reconcileServiceMonitor, r.reconcileNetworkPolicy)
+		reconcileSteps = append(reconcileSteps,
+			reconcileLSStep{r.reconcileServiceMonitor, "ServiceMonitor"},
+			reconcileLSStep{r.reconcileNetworkPolicy, "NetworkPolicy"})
 	}
 
-	for _, reconcileFunction := range reconcileFunctions {
-		recResult, err = reconcileFunction.(reconcileLSFunctionType)(instance)
-		if err != nil || recResult.Requeue {
+	for _, step := range reconcileSteps {
+		recResult, err = step.reconcile(instance)
+		if err != nil {
+			operatorv1alpha1.SetDegradedConditions(&foundInstance.Status.Conditions, instance.GetGeneration(),
+				step.component+"ReconcileFailed", err.Error())
+			r.updateConditions(foundInstance, instance, reqLogger)
 			return recResult, err
 		}
 	}
 
+	// resources are applied without waiting for them, Progressing is set from the rollout of the deployment
 	// Update status logic, using foundInstance, because we do not want to add filled default values to yaml
-	return r.updateStatus(foundInstance, reqLogger)
+	foundInstance.Status.Certificates = instance.Status.Certificates
+	foundInstance.Status.TokenRotation = instance.Status.TokenRotation
+	recResult, err = r.updateStatus(foundInstance, instance, reqLogger)
+	if err == nil {
+		recResult.RequeueAfter = getRequeueDelay(instance, time.Now())
+	}
+	return recResult, err
 }
 
-func (r *IBMLicensingReconciler) updateStatus(instance *operatorv1alpha1.IBMLicensing, reqLogger logr.Logger) (reconcile.Result, error) {
+// getRequeueDelay returns time until the earliest renewal of certificates issued by the operator CA or rotation of tokens,
+// which are done by reconciliation
+func getRequeueDelay(instance *operatorv1alpha1.IBMLicensing, now time.Time) time.Duration {
+	delay := res.GetRenewalDelay(instance.Status.Certificates, now)
+	if tokenDelay := res.GetTokenRotationDelay(instance.Status.TokenRotation, now); tokenDelay > 0 && (delay == 0 || tokenDelay < delay) {
+		delay = tokenDelay
+	}
+	return delay
+}
+
+// updateStatus sets pods statuses and conditions of foundInstance, instance with filled default values is used to find operands
+func (r *IBMLicensingReconciler) updateStatus(foundInstance, instance *operatorv1alpha1.IBMLicensing, reqLogger logr.Logger) (reconcile.Result, error) {
 	podList := &corev1.PodList{}
 	listOpts := []client.ListOption{
 		client.InNamespace(instance.Spec.InstanceNamespace),
@@ -193,32 +340,81 @@ func (r *IBMLicensingReconciler) updateStatus(instance *operatorv1alpha1.IBMLice
 		}
 		podStatuses = append(podStatuses, pod.Status)
 	}
+	foundInstance.Status.LicensingPods = podStatuses
 
-	if !reflect.DeepEqual(podStatuses, instance.Status.LicensingPods) {
-		reqLogger.Info("Updating IBMLicensing status")
-		instance.Status.LicensingPods = podStatuses
-		err := r.Client.Status().Update(context.TODO(), instance)
-		if err != nil {
-			reqLogger.Info("Warning: Failed to update pod status, this does not affect License Service")
-		}
+	reason, message, err := r.getReadiness(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to check readiness of License Service")
+		return reconcile.Result{}, err
+	}
+	if reason == operatorv1alpha1.ReasonAvailable {
+		operatorv1alpha1.SetAvailableConditions(&foundInstance.Status.Conditions, instance.GetGeneration(), reason, message)
+	} else {
+		operatorv1alpha1.SetProgressingConditions(&foundInstance.Status.Conditions, instance.GetGeneration(), reason, message)
 	}
+	r.updateConditions(foundInstance, instance, reqLogger)
 
 	reqLogger.Info("reconcile all done")
 	return reconcile.Result{}, nil
 }
 
+// getReadiness returns reason and message for Ready condition based on License Service deployment state
+func (r *IBMLicensingReconciler) getReadiness(instance *operatorv1alpha1.IBMLicensing) (string, string, error) {
+	deployment := &appsv1.Deployment{}
+	namespacedName := types.NamespacedName{Name: service.GetResourceName(instance), Namespace: instance.Spec.InstanceNamespace}
+	if err := r.Client.Get(context.TODO(), namespacedName, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return operatorv1alpha1.ReasonWaitingForDeployment, "License Service deployment does not exist yet", nil
+		}
+		return "", "", err
+	}
+	if deployment.Status.AvailableReplicas > 0 && deployment.Status.UpdatedReplicas == deployment.Status.Replicas {
+		return operatorv1alpha1.ReasonAvailable, "License Service is available", nil
+	}
+	if certSecretName := service.GetCertSecretName(instance, r.Capabilities.Get()); certSecretName != "" {
+		secret := &corev1.Secret{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: certSecretName, Namespace: instance.Spec.InstanceNamespace}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return operatorv1alpha1.ReasonWaitingForCertSecret, "Waiting for " + certSecretName + " secret with certificate", nil
+			}
+			return "", "", err
+		}
+	}
+	return operatorv1alpha1.ReasonWaitingForDeployment, "Waiting for License Service pods to become available", nil
+}
+
+// updateConditions writes foundInstance status if it differs from the one stored in the cluster
+func (r *IBMLicensingReconciler) updateConditions(foundInstance, instance *operatorv1alpha1.IBMLicensing, reqLogger logr.Logger) {
+	foundInstance.Status.ObservedGeneration = instance.GetGeneration()
+	storedInstance := &operatorv1alpha1.IBMLicensing{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: foundInstance.GetName()}, storedInstance); err != nil {
+		reqLogger.Info("Warning: Failed to get IBMLicensing status, this does not affect License Service")
+		return
+	}
+	if reflect.DeepEqual(storedInstance.Status, foundInstance.Status) {
+		return
+	}
+	reqLogger.Info("Updating IBMLicensing status")
+	storedInstance.Status = foundInstance.Status
+	if err := r.Client.Status().Update(context.TODO(), storedInstance); err != nil {
+		reqLogger.Info("Warning: Failed to update IBMLicensing status, this does not affect License Service")
+	}
+}
+
 func (r *IBMLicensingReconciler) reconcileAPISecretToken(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("reconcileAPISecretToken", "Entry", "instance.GetName()", instance.GetName())
 	expectedSecret, err := service.GetAPISecretToken(instance)
 	if err != nil {
 		reqLogger.Info("Failed to get expected secret")
-		return reconcile.Result{
-			Requeue:      true,
-			RequeueAfter: time.Minute,
-		}, err
+		return reconcile.Result{}, err
 	}
 	foundSecret := &corev1.Secret{}
-	return r.reconcileResourceNamespacedExistence(instance, expectedSecret, foundSecret)
+	result, err := r.reconcileResourceNamespacedExistence(instance, expectedSecret, foundSecret)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+	return r.rotateToken(instance, foundSecret, service.APISecretTokenKeyName)
 }
 
 func (r *IBMLicensingReconciler) reconcileUploadToken(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
@@ -226,61 +422,66 @@ func (r *IBMLicensingReconciler) reconcileUploadToken(instance *operatorv1alpha1
 	expectedSecret, err := service.GetUploadToken(instance)
 	if err != nil {
 		reqLogger.Info("Failed to get expected secret")
-		return reconcile.Result{
-			Requeue:      true,
-			RequeueAfter: time.Minute,
-		}, err
+		return reconcile.Result{}, err
 	}
 	foundSecret := &corev1.Secret{}
-	return r.reconcileResourceNamespacedExistence(instance, expectedSecret, foundSecret)
+	result, err := r.reconcileResourceNamespacedExistence(instance, expectedSecret, foundSecret)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+	return r.rotateToken(instance, foundSecret, service.APIUploadTokenKeyName)
+}
+
+// rotateToken rotates token in the secret created by the operator, when token rotation is configured, and records
+// the rotation in the status, secrets provided by the user are not changed
+func (r *IBMLicensingReconciler) rotateToken(instance *operatorv1alpha1.IBMLicensing, secret *corev1.Secret, key string) (reconcile.Result, error) {
+	if !metav1.IsControlledBy(secret, instance) {
+		return reconcile.Result{}, nil
+	}
+	changed, status, err := res.RotateSecretToken(secret, key, instance.Spec.TokenRotation, time.Now())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if changed {
+		r.Log.Info("Updating token rotation of secret", "Namespace", secret.GetNamespace(), "Name", secret.GetName())
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if status != nil {
+		instance.Status.TokenRotation = append(instance.Status.TokenRotation, *status)
+	}
+	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicensingReconciler) reconcileConfigMaps(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileConfigMaps", "Entry", "instance.GetName()", instance.GetName())
 	expectedCMs := []*corev1.ConfigMap{
 		service.GetUploadConfigMap(instance),
 		service.GetInfoConfigMap(instance),
 	}
 	for _, expectedCM := range expectedCMs {
-		foundCM := &corev1.ConfigMap{}
-		reconcileResult, err := r.reconcileResourceNamespacedExistence(instance, expectedCM, foundCM)
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
-		}
-		if !res.CompareConfigMap(expectedCM, foundCM) {
-			if updateReconcileResult, err := res.UpdateResource(&reqLogger, r.Client, expectedCM, foundCM); err != nil || updateReconcileResult.Requeue {
-				return updateReconcileResult, err
-			}
+		if result, err := r.applyResource(instance, instance, expectedCM); err != nil || result.Requeue {
+			return result, err
 		}
-
 	}
 	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicensingReconciler) reconcileServices(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
-	var (
-		result reconcile.Result
-		err    error
-	)
-	reqLogger := r.Log.WithValues("reconcileServices", "Entry", "instance.GetName()", instance.GetName())
-	expected, notExpected := service.GetServices(instance)
-	found := &corev1.Service{}
+	expected, notExpected := service.GetServices(instance, r.Capabilities.Get())
 	for _, es := range expected {
-		result, err = r.reconcileResourceNamespacedExistence(instance, es, found)
-		if err != nil || result.Requeue {
+		if result, err := r.applyResource(instance, instance, es); err != nil || result.Requeue {
 			return result, err
 		}
-		result, err = res.UpdateServiceIfNeeded(&reqLogger, r.Client, es, found)
 	}
 
 	for _, ne := range notExpected {
-		result, err = r.reconcileNamespacedResourceWhichShouldNotExist(instance, ne, found)
-		if err != nil || result.Requeue {
+		if result, err := r.reconcileNamespacedResourceWhichShouldNotExist(instance, ne, &corev1.Service{}); err != nil || result.Requeue {
 			return result, err
 		}
 	}
 
-	return result, err
+	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicensingReconciler) reconcileServiceMonitor(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
@@ -288,20 +489,12 @@ func (r *IBMLicensingReconciler) reconcileServiceMonitor(instance *operatorv1alp
 		return reconcile.Result{}, nil
 	}
 	reqLogger := r.Log.WithValues("reconcileServiceMonitor", "Entry", "instance.GetName()", instance.GetName())
-	expectedServiceMonitor := service.GetServiceMonitor(instance)
-	owner := service.GetPrometheusService(instance)
+	owner := service.GetPrometheusService(instance, r.Capabilities.Get())
 	result, err := res.UpdateOwner(&reqLogger, r.Client, owner)
 	if err != nil || result.Requeue {
 		return result, err
 	}
-	foundServiceMonitor := &monitoringv1.ServiceMonitor{}
-	result, err = r.reconcileResourceNamespacedExistenceWithCustomController(instance, owner, expectedServiceMonitor, foundServiceMonitor)
-	if err != nil || result.Requeue {
-		return result, err
-	}
-	result, err = res.UpdateServiceMonitor(&reqLogger, r.Client, expectedServiceMonitor, foundServiceMonitor)
-
-	return result, err
+	return r.applyResource(instance, owner, service.GetServiceMonitor(instance))
 }
 
 func (r *IBMLicensingReconciler) reconcileNetworkPolicy(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
@@ -309,91 +502,83 @@ func (r *IBMLicensingReconciler) reconcileNetworkPolicy(instance *operatorv1alph
 		return reconcile.Result{}, nil
 	}
 	reqLogger := r.Log.WithValues("reconcileNetworkPolicy", "Entry", "instance.GetName()", instance.GetName())
-	expected := service.GetNetworkPolicy(instance)
-	owner := service.GetPrometheusService(instance)
+	owner := service.GetPrometheusService(instance, r.Capabilities.Get())
 	result, err := res.UpdateOwner(&reqLogger, r.Client, owner)
 	if err != nil || result.Requeue {
 		return result, err
 	}
-	found := &networkingv1.NetworkPolicy{}
-	result, err = r.reconcileResourceNamespacedExistenceWithCustomController(instance, owner, expected, found)
-	if err != nil || result.Requeue {
-		return result, err
-	}
-	result, err = res.UpdateResource(&reqLogger, r.Client, expected, found)
-
-	return result, err
+	return r.applyResource(instance, owner, service.GetNetworkPolicy(instance))
 }
 
-func (r *IBMLicensingReconciler) reconcileDeployment(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
-	reqLogger := r.Log.WithValues("reconcileDeployment", "Entry", "instance.GetName()", instance.GetName())
-	expectedDeployment := service.GetLicensingDeployment(instance)
-
-	foundDeployment := &appsv1.Deployment{}
-	reconcileResult, err := r.reconcileResourceNamespacedExistence(instance, expectedDeployment, foundDeployment)
-	if err != nil || reconcileResult.Requeue {
-		return reconcileResult, err
+// reconcileCertificates applies cert-manager Certificates of License Service, when cert-manager issues its certificates,
+// or secrets with certificates issued by the operator CA
+func (r *IBMLicensingReconciler) reconcileCertificates(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
+	instance.Status.Certificates = nil
+	if !instance.Spec.HTTPSEnable {
+		return reconcile.Result{}, nil
 	}
-
-	shouldUpdate := res.ShouldUpdateDeployment(
-		&reqLogger,
-		&expectedDeployment.Spec.Template,
-		&foundDeployment.Spec.Template,
-	)
-	if shouldUpdate {
-		return res.UpdateResource(&reqLogger, r.Client, expectedDeployment, foundDeployment)
+	if instance.Spec.HTTPSCertsSource == operatorv1alpha1.OperatorCACertsSource {
+		return r.reconcileOperatorCACertificates(instance)
+	}
+	if instance.Spec.HTTPSCertsSource != operatorv1alpha1.CertManagerCertsSource {
+		return reconcile.Result{}, nil
+	}
+	if err := res.CheckCertManager(r.Capabilities.Get(), instance.Spec.CertManagerIssuer); err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, certificate := range service.GetCertificates(instance) {
+		if result, err := r.applyResource(instance, instance, certificate); err != nil || result.Requeue {
+			return result, err
+		}
 	}
-
 	return reconcile.Result{}, nil
 }
 
-func (r *IBMLicensingReconciler) reconcileRoute(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
-	if res.IsRouteAPI && instance.Spec.IsRouteEnabled() {
-		expectedRoute := service.GetLicensingRoute(instance)
-		foundRoute := &routev1.Route{}
-		reconcileResult, err := r.reconcileResourceNamespacedExistence(instance, expectedRoute, foundRoute)
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
+// reconcileOperatorCACertificates applies secrets with certificates issued by the operator CA and publishes the CA bundle,
+// expiry of the certificates is recorded in the status
+func (r *IBMLicensingReconciler) reconcileOperatorCACertificates(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("reconcileOperatorCACertificates", "Entry", "instance.GetName()", instance.GetName())
+	namespace := instance.Spec.InstanceNamespace
+	now := time.Now()
+	ca, err := res.GetOperatorCA(r.Reader, r.Client, namespace, now)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result, err := res.ApplyResource(&reqLogger, r.Client, r.Scheme, res.GetCABundleConfigMap(namespace, ca.Bundle)); err != nil {
+		return result, err
+	}
+	secrets, statuses, err := service.GetCertificateSecrets(r.Reader, instance, ca, now)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, secret := range secrets {
+		if result, err := r.applyResource(instance, instance, secret); err != nil || result.Requeue {
+			return result, err
 		}
-		reqLogger := r.Log.WithValues("reconcileRoute", "Entry", "instance.GetName()", instance.GetName())
+	}
+	instance.Status.Certificates = append([]operatorv1alpha1.CertificateStatus{ca.Status}, statuses...)
+	return reconcile.Result{}, nil
+}
 
-		if !res.CompareRoutes(reqLogger, expectedRoute, foundRoute) {
-			return res.UpdateResource(&reqLogger, r.Client, expectedRoute, foundRoute)
-		}
+func (r *IBMLicensingReconciler) reconcileDeployment(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
+	capabilities := r.Capabilities.Get()
+	configHashes, err := res.GetConfigHashes(r.Client, instance.Spec.InstanceNamespace, service.GetConfigReferences(instance, capabilities))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.applyResource(instance, instance, service.GetLicensingDeployment(instance, capabilities, configHashes))
+}
+
+func (r *IBMLicensingReconciler) reconcileRoute(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
+	if r.Capabilities.Get().RouteAPI && instance.Spec.IsRouteEnabled() {
+		return r.applyResource(instance, instance, service.GetLicensingRoute(instance))
 	}
 	return reconcile.Result{}, nil
 }
 
 func (r *IBMLicensingReconciler) reconcileIngress(instance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
 	if instance.Spec.IsIngressEnabled() {
-		expectedIngress := service.GetLicensingIngress(instance)
-		foundIngress := &networkingv1.Ingress{}
-		reconcileResult, err := r.reconcileResourceNamespacedExistence(instance, expectedIngress, foundIngress)
-		if err != nil || reconcileResult.Requeue {
-			return reconcileResult, err
-		}
-		reqLogger := r.Log.WithValues("reconcileIngress", "Entry", "instance.GetName()", instance.GetName())
-		possibleUpdateNeeded := true
-		if foundIngress.ObjectMeta.Name != expectedIngress.ObjectMeta.Name {
-			reqLogger.Info("Names not equal", "old", foundIngress.ObjectMeta.Name, "new", expectedIngress.ObjectMeta.Name)
-		} else if !reflect.DeepEqual(foundIngress.ObjectMeta.Labels, expectedIngress.ObjectMeta.Labels) {
-			reqLogger.Info("Labels not equal",
-				"old", fmt.Sprintf("%v", foundIngress.ObjectMeta.Labels),
-				"new", fmt.Sprintf("%v", expectedIngress.ObjectMeta.Labels))
-		} else if !reflect.DeepEqual(foundIngress.ObjectMeta.Annotations, expectedIngress.ObjectMeta.Annotations) {
-			reqLogger.Info("Annotations not equal",
-				"old", fmt.Sprintf("%v", foundIngress.ObjectMeta.Annotations),
-				"new", fmt.Sprintf("%v", expectedIngress.ObjectMeta.Annotations))
-		} else if !reflect.DeepEqual(foundIngress.Spec, expectedIngress.Spec) {
-			reqLogger.Info("Specs not equal",
-				"old", fmt.Sprintf("%v", foundIngress.Spec),
-				"new", fmt.Sprintf("%v", expectedIngress.Spec))
-		} else {
-			possibleUpdateNeeded = false
-		}
-		if possibleUpdateNeeded {
-			return res.UpdateResource(&reqLogger, r.Client, expectedIngress, foundIngress)
-		}
+		return r.applyResource(instance, instance, service.GetLicensingIngress(instance))
 	}
 	return reconcile.Result{}, nil
 }
@@ -402,36 +587,15 @@ func (r *IBMLicensingReconciler) reconcileMeterDefinition(instance *operatorv1al
 	if !instance.Spec.IsRHMPEnabled() {
 		return reconcile.Result{}, nil
 	}
-	reqLogger := r.Log.WithValues("reconcileMeterDefinition", "Entry", "instance.GetName()", instance.GetName())
-	expected := service.GetMeterDefinition(instance)
-	found := &rhmp.MeterDefinition{}
-	owner := service.GetPrometheusService(instance)
+	owner := service.GetPrometheusService(instance, r.Capabilities.Get())
 	result, err := res.UpdateOwner(&r.Log, r.Client, owner)
 	if err != nil || result.Requeue {
 		return result, err
 	}
-	for _, es := range expected {
-		result, err := r.reconcileResourceNamespacedExistenceWithCustomController(instance, owner, es, found)
-		if err != nil || result.Requeue {
+	for _, es := range service.GetMeterDefinition(instance) {
+		if result, err := r.applyResource(instance, owner, es); err != nil || result.Requeue {
 			return result, err
 		}
-		possibleUpdateNeeded := true
-		if found.ObjectMeta.Name != es.ObjectMeta.Name {
-			reqLogger.Info("Names not equal", "old", found.ObjectMeta.Name, "new", es.ObjectMeta.Name)
-		} else if found.Spec.Kind != es.Spec.Kind {
-			reqLogger.Info("Found wrong Kind")
-		} else if len(found.Spec.Meters) == 0 {
-			reqLogger.Info("Found MeterDefinition without Meters")
-		} else if len(found.Spec.Meters) > 0 && found.Spec.Meters[0].Query != es.Spec.Meters[0].Query {
-			reqLogger.Info("Found MeterDefinition with wrong Query",
-				"old", fmt.Sprintf("%v", found.Spec.Meters[0].Query),
-				"new", fmt.Sprintf("%v", es.Spec.Meters[0].Query))
-		} else {
-			possibleUpdateNeeded = false
-		}
-		if possibleUpdateNeeded {
-			return res.UpdateResource(&reqLogger, r.Client, es, found)
-		}
 	}
 	return reconcile.Result{}, nil
 }
@@ -440,19 +604,23 @@ func (r *IBMLicensingReconciler) reconcileResourceNamespacedExistence(
 	instance *operatorv1alpha1.IBMLicensing, expectedRes res.ResourceObject, foundRes runtime.Object) (reconcile.Result, error) {
 
 	namespacedName := types.NamespacedName{Name: expectedRes.GetName(), Namespace: expectedRes.GetNamespace()}
-	return r.reconcileResourceExistence(instance, instance, expectedRes, foundRes, namespacedName)
+	return r.reconcileResourceExistence(instance, expectedRes, foundRes, namespacedName)
 }
 
-func (r *IBMLicensingReconciler) reconcileResourceNamespacedExistenceWithCustomController(
-	instance *operatorv1alpha1.IBMLicensing, controller, expectedRes res.ResourceObject, foundRes runtime.Object) (reconcile.Result, error) {
+// applyResource sets controller of expected resource and applies it with server-side apply
+func (r *IBMLicensingReconciler) applyResource(
+	instance *operatorv1alpha1.IBMLicensing, controller metav1.Object, expectedRes res.ResourceObject) (reconcile.Result, error) {
 
-	namespacedName := types.NamespacedName{Name: expectedRes.GetName(), Namespace: expectedRes.GetNamespace()}
-	return r.reconcileResourceExistence(instance, controller, expectedRes, foundRes, namespacedName)
+	reqLogger := r.Log.WithValues(reflect.TypeOf(expectedRes).String(), "Entry", "instance.GetName()", instance.GetName())
+	if err := controllerutil.SetControllerReference(controller, expectedRes, r.Scheme); err != nil {
+		reqLogger.Error(err, "Failed to define expected resource")
+		return reconcile.Result{}, err
+	}
+	return res.ApplyResource(&reqLogger, r.Client, r.Scheme, expectedRes)
 }
 
 func (r *IBMLicensingReconciler) reconcileResourceExistence(
 	instance *operatorv1alpha1.IBMLicensing,
-	controller metav1.Object,
 	expectedRes res.ResourceObject,
 	foundRes runtime.Object,
 	namespacedName types.NamespacedName) (reconcile.Result, error) {
@@ -461,7 +629,7 @@ func (r *IBMLicensingReconciler) reconcileResourceExistence(
 	reqLogger := r.Log.WithValues(resType.String(), "Entry", "instance.GetName()", instance.GetName())
 
 	// expectedRes already set before and passed via parameter
-	err := controllerutil.SetControllerReference(controller, expectedRes, r.Scheme)
+	err := controllerutil.SetControllerReference(instance, expectedRes, r.Scheme)
 	if err != nil {
 		reqLogger.Error(err, "Failed to define expected resource")
 		return reconcile.Result{}, err
@@ -481,9 +649,8 @@ func (r *IBMLicensingReconciler) reconcileResourceExistence(
 					return reconcile.Result{}, err
 				}
 			}
-			// Created successfully, or already exists - return and requeue
-			time.Sleep(time.Second * 5)
-			return reconcile.Result{Requeue: true, RequeueAfter: time.Second}, nil
+			// Created successfully, or already exists - following resources are reconciled in the same pass
+			return reconcile.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed to get "+resType.String(), "Name", expectedRes.GetName(),
 			"Namespace", expectedRes.GetNamespace())
@@ -521,13 +688,13 @@ func (r *IBMLicensingReconciler) reconcileResourceWhichShouldNotExist(
 	return res.DeleteResource(&reqLogger, r.Client, expectedRes)
 }
 
-func (r *IBMLicensingReconciler) controllerStatus(instance *operatorv1alpha1.IBMLicensing) {
-	if res.IsRouteAPI {
+func (r *IBMLicensingReconciler) controllerStatus(instance *operatorv1alpha1.IBMLicensing, capabilities res.Capabilities) {
+	if capabilities.RouteAPI {
 		r.Log.Info("Route feature is enabled")
 	} else {
 		r.Log.Info("Route feature is disabled")
 	}
-	if res.IsServiceCAAPI {
+	if capabilities.ServiceCAAPI {
 		r.Log.Info("ServiceCA feature is enabled")
 	} else {
 		r.Log.Info("ServiceCA feature is disabled")
@@ -544,3 +711,97 @@ func (r *IBMLicensingReconciler) controllerStatus(instance *operatorv1alpha1.IBM
 	}
 
 }
+
+// reconcileFinalizer adds finalizer when spec.decommission.snapshotOnDelete is set, and removes it otherwise
+func (r *IBMLicensingReconciler) reconcileFinalizer(foundInstance *operatorv1alpha1.IBMLicensing) error {
+	hasFinalizer := controllerutil.ContainsFinalizer(foundInstance, service.SnapshotFinalizer)
+	switch {
+	case foundInstance.Spec.IsSnapshotOnDelete() && !hasFinalizer:
+		controllerutil.AddFinalizer(foundInstance, service.SnapshotFinalizer)
+	case !foundInstance.Spec.IsSnapshotOnDelete() && hasFinalizer:
+		controllerutil.RemoveFinalizer(foundInstance, service.SnapshotFinalizer)
+	default:
+		return nil
+	}
+	if err := r.Client.Update(context.TODO(), foundInstance); err != nil {
+		r.Log.Error(err, "Failed to update finalizers", "instance", foundInstance.GetName())
+		return err
+	}
+	return nil
+}
+
+// finalize stores audit snapshot in a secret, which is not owned by the instance, records its location in an event
+// and removes the finalizer, failed snapshot is retried
+func (r *IBMLicensingReconciler) finalize(foundInstance *operatorv1alpha1.IBMLicensing) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(foundInstance, service.SnapshotFinalizer) {
+		return reconcile.Result{}, nil
+	}
+	reqLogger := r.Log.WithValues("finalize", "Entry", "instance.GetName()", foundInstance.GetName())
+
+	if foundInstance.Spec.IsSnapshotOnDelete() {
+		instance := foundInstance.DeepCopy()
+		capabilities := r.Capabilities.Get()
+		err := instance.Spec.FillDefaultValues(capabilities.ServiceCAAPI, capabilities.RouteAPI, res.DefaultRHMPEnabled, r.OperatorNamespace)
+		if err == nil {
+			err = r.storeFinalSnapshot(instance)
+		}
+		if err != nil {
+			reqLogger.Error(err, "Failed to store audit snapshot")
+			r.Recorder.Event(foundInstance, corev1.EventTypeWarning, "FinalSnapshotFailed",
+				"Audit snapshot failed, deletion is retried, set spec.decommission.snapshotOnDelete to false to delete "+
+					"without it: "+err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(foundInstance, service.SnapshotFinalizer)
+	if err := r.Client.Update(context.TODO(), foundInstance); err != nil {
+		reqLogger.Error(err, "Failed to remove finalizer")
+		return reconcile.Result{}, err
+	}
+	reqLogger.Info("Finalizer removed")
+	return reconcile.Result{}, nil
+}
+
+// storeFinalSnapshot retrieves audit snapshot from License Service and applies it in the final snapshot secret
+func (r *IBMLicensingReconciler) storeFinalSnapshot(instance *operatorv1alpha1.IBMLicensing) error {
+	reqLogger := r.Log.WithValues("storeFinalSnapshot", "Entry", "instance.GetName()", instance.GetName())
+	data, err := service.GetAuditSnapshot(r.Client, instance, nil, service.MaxFinalSnapshotSize)
+	if err != nil {
+		return err
+	}
+	snapshotSecret := service.GetFinalSnapshotSecret(instance, data)
+	message := "Audit snapshot stored in secret " + snapshotSecret.GetNamespace() + "/" + snapshotSecret.GetName() + " under " +
+		service.SnapshotKey + " key"
+	if signingKeySecret := instance.Spec.GetSigningKeySecret(); signingKeySecret != "" {
+		if err := r.signFinalSnapshot(snapshotSecret, signingKeySecret); err != nil {
+			return err
+		}
+		message += ", signature stored under " + service.SnapshotKey + snapshot.SignatureSuffix + " key"
+	}
+	if _, err := res.ApplyResource(&reqLogger, r.Client, r.Scheme, snapshotSecret); err != nil {
+		return err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "FinalSnapshotStored", message)
+	return nil
+}
+
+// signFinalSnapshot adds SHA-256 manifest of audit snapshot and its detached signature to the final snapshot secret
+func (r *IBMLicensingReconciler) signFinalSnapshot(snapshotSecret *corev1.Secret, signingKeySecret string) error {
+	signingKey, err := res.GetSecretValue(r.Client, signingKeySecret, snapshotSecret.GetNamespace(), snapshot.SigningKeyKey)
+	if err != nil {
+		return err
+	}
+	key, err := snapshot.ParsePrivateKey([]byte(signingKey))
+	if err != nil {
+		return fmt.Errorf("signing key secret %s is invalid: %w", signingKeySecret, err)
+	}
+	manifest := snapshot.GetManifest(service.SnapshotKey, snapshotSecret.Data[service.SnapshotKey])
+	signature, err := snapshot.Sign(key, manifest)
+	if err != nil {
+		return err
+	}
+	snapshotSecret.Data[service.SnapshotKey+snapshot.ManifestSuffix] = manifest
+	snapshotSecret.Data[service.SnapshotKey+snapshot.SignatureSuffix] = signature
+	return nil
+}
@@ -18,24 +18,68 @@ package controllers
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
 	"testing"
+	"time"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/controllers/resources/service"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	routev1 "github.com/openshift/api/route/v1"
 	rhmp "github.com/redhat-marketplace/redhat-marketplace-operator/v2/apis/marketplace/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestCheckReconcileLicensing(t *testing.T) {
 }
 
+func TestSignedFinalSnapshotFitsInSecret(t *testing.T) {
+	const namespace = "ibm-common-services"
+	// RSA signature is as large as the key, unlike ed25519 signature
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingKeySecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "signing-key", Namespace: namespace},
+		Data: map[string][]byte{snapshot.SigningKeyKey: pem.EncodeToMemory(
+			&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})},
+	}
+	r := &IBMLicensingReconciler{Client: fake.NewFakeClientWithScheme(scheme.Scheme, signingKeySecret)}
+
+	instance := &operatorv1alpha1.IBMLicensing{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance"},
+		Spec:       operatorv1alpha1.IBMLicensingSpec{InstanceNamespace: namespace},
+	}
+	snapshotSecret := service.GetFinalSnapshotSecret(instance, make([]byte, service.MaxFinalSnapshotSize))
+	if err := r.signFinalSnapshot(snapshotSecret, signingKeySecret.GetName()); err != nil {
+		t.Fatal(err)
+	}
+	size := 0
+	for key, value := range snapshotSecret.Data {
+		size += len(key) + len(value)
+	}
+	if size > res.MaxSecretDataSize {
+		t.Errorf("final snapshot secret data has %d bytes, which exceeds %d bytes", size, res.MaxSecretDataSize)
+	}
+}
+
 var _ = Describe("IBMLicensing controller", func() {
 	const (
 		name = "instance-test"
@@ -297,9 +341,114 @@ var _ = Describe("IBMLicensing controller", func() {
 				return newInstance.Spec.IsChargebackEnabled()
 			}, timeout, interval).Should(Equal(true))
 		})
+
+		It("Should keep replicas of License Service scaled by others", func() {
+			By("Creating the IBMLicensing")
+			newInstance := &operatorv1alpha1.IBMLicensing{}
+
+			instance = &operatorv1alpha1.IBMLicensing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: operatorv1alpha1.IBMLicensingSpec{
+					InstanceNamespace: namespace,
+					Datasource:        "datacollector",
+					Container: operatorv1alpha1.Container{
+						ImagePullPolicy: v1.PullAlways,
+					},
+					UsageContainer: operatorv1alpha1.Container{
+						ImagePullPolicy: v1.PullAlways,
+					},
+					IBMLicenseServiceBaseSpec: operatorv1alpha1.IBMLicenseServiceBaseSpec{
+						ImagePullSecrets: []string{"artifactory-token"},
+					},
+				},
+			}
+
+			checkBasicRequirements(ctx, instance, newInstance)
+
+			By("Scaling License Service deployment")
+			deploymentName := types.NamespacedName{Name: service.GetResourceName(instance), Namespace: namespace}
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, deploymentName, deployment)).Should(Succeed())
+			scaledReplicas := int32(2)
+			patch := client.MergeFrom(deployment.DeepCopy())
+			deployment.Spec.Replicas = &scaledReplicas
+			Expect(k8sClient.Patch(ctx, deployment, patch)).Should(Succeed())
+
+			By("Changing the IBMLicensing, so that deployment is applied again")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: instance.Name}, newInstance)).Should(Succeed())
+			newInstance.Spec.LogLevel = "DEBUG"
+			Expect(k8sClient.Update(ctx, newInstance)).Should(Succeed())
+			Eventually(func() bool {
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: instance.Name}, newInstance)).Should(Succeed())
+				return newInstance.Status.ObservedGeneration == newInstance.Generation
+			}, timeout, interval).Should(BeTrue())
+
+			By("Checking if replicas of the deployment are kept")
+			Consistently(func() int32 {
+				Expect(k8sClient.Get(ctx, deploymentName, deployment)).Should(Succeed())
+				return *deployment.Spec.Replicas
+			}, time.Second*30, interval).Should(Equal(scaledReplicas))
+		})
 	})
 })
 
+func TestRetiringPreviousTokenDoesNotRollOutPods(t *testing.T) {
+	const namespace = "ibm-common-services"
+	instance := &operatorv1alpha1.IBMLicensing{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance"},
+		Spec: operatorv1alpha1.IBMLicensingSpec{
+			IBMLicenseServiceBaseSpec: operatorv1alpha1.IBMLicenseServiceBaseSpec{APISecretToken: "ibm-licensing-token"},
+			InstanceNamespace:         namespace,
+			TokenRotation:             &operatorv1alpha1.IBMLicensingTokenRotation{Interval: metav1.Duration{Duration: 720 * time.Hour}},
+		},
+	}
+	tokenSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: instance.Spec.APISecretToken, Namespace: namespace},
+		Data: map[string][]byte{
+			service.APISecretTokenKeyName:                              []byte("current"),
+			service.APISecretTokenKeyName + res.PreviousTokenKeySuffix: []byte("previous"),
+		},
+	}
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme, tokenSecret)
+	getPodAnnotations := func() map[string]string {
+		hashes, err := res.GetConfigHashes(reader, namespace, service.GetConfigReferences(instance, res.Capabilities{}))
+		if err != nil {
+			t.Fatalf("failed to get config hashes: %v", err)
+		}
+		return service.GetLicensingDeployment(instance, res.Capabilities{}, hashes).Spec.Template.Annotations
+	}
+
+	deployment := service.GetLicensingDeployment(instance, res.Capabilities{}, nil)
+	previousTokensMounted := false
+	for _, mount := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if mount.Name == service.PreviousTokensVolumeName && mount.SubPath == "" {
+			previousTokensMounted = true
+		}
+	}
+	if !previousTokensMounted {
+		t.Error("expected previous tokens to be mounted without subPath, so that they are removed when they expire")
+	}
+
+	initial := getPodAnnotations()
+	delete(tokenSecret.Data, service.APISecretTokenKeyName+res.PreviousTokenKeySuffix)
+	if err := reader.Update(context.TODO(), tokenSecret); err != nil {
+		t.Fatal(err)
+	}
+	if retired := getPodAnnotations(); !reflect.DeepEqual(initial, retired) {
+		t.Errorf("expected pod annotations not to change when previous token is retired, got %v instead of %v", retired, initial)
+	}
+
+	tokenSecret.Data[service.APISecretTokenKeyName] = []byte("rotated")
+	if err := reader.Update(context.TODO(), tokenSecret); err != nil {
+		t.Fatal(err)
+	}
+	if rotated := getPodAnnotations(); reflect.DeepEqual(initial, rotated) {
+		t.Error("expected pod annotations to change when token is rotated")
+	}
+}
+
 func checkBasicRequirements(ctx context.Context, instance, newInstance *operatorv1alpha1.IBMLicensing) {
 	Expect(k8sClient.Create(ctx, instance)).Should(Succeed())
 
@@ -314,6 +463,13 @@ func checkBasicRequirements(ctx context.Context, instance, newInstance *operator
 		return newInstance.Status.LicensingPods[0].Phase
 	}, timeout, interval).Should(Equal(v1.PodRunning))
 
+	By("Checking Ready condition of the IBMLicensing")
+	Eventually(func() bool {
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: instance.Name}, newInstance)).Should(Succeed())
+		return meta.IsStatusConditionTrue(newInstance.Status.Conditions, operatorv1alpha1.ConditionReady) &&
+			newInstance.Status.ObservedGeneration == newInstance.Generation
+	}, timeout, interval).Should(BeTrue())
+
 	By("Checking if licensing-service exists")
 	Eventually(func() bool {
 		licensingService := &v1.Service{}
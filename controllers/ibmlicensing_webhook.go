@@ -0,0 +1,253 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/service"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// IBMLicensingValidatingWebhookPath is the path on which IBMLicensing validating webhook is served
+const IBMLicensingValidatingWebhookPath = "/validate-operator-ibm-com-v1alpha1-ibmlicensing"
+
+// IBMLicensingDefaultingWebhookPath is the path on which IBMLicensing defaulting webhook is served
+const IBMLicensingDefaultingWebhookPath = "/mutate-operator-ibm-com-v1alpha1-ibmlicensing"
+
+// +kubebuilder:webhook:path=/validate-operator-ibm-com-v1alpha1-ibmlicensing,mutating=false,failurePolicy=fail,groups=operator.ibm.com,resources=ibmlicensings,verbs=create;update,versions=v1alpha1,matchPolicy=Equivalent,name=vibmlicensing.operator.ibm.com,sideEffects=None
+
+// IBMLicensingValidator rejects invalid IBMLicensing configurations and warns about ones that can not work yet
+type IBMLicensingValidator struct {
+	Client            client.Client
+	Log               logr.Logger
+	OperatorNamespace string
+	Capabilities      *CapabilityDetector
+	decoder           *admission.Decoder
+}
+
+// blank assignment to verify that IBMLicensingValidator implements admission.Handler
+var _ admission.Handler = &IBMLicensingValidator{}
+
+// Handle validates IBMLicensing on create and update
+func (v *IBMLicensingValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	instance := &operatorv1alpha1.IBMLicensing{}
+	if err := v.decoder.Decode(req, instance); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	validation := &webhookValidation{}
+
+	if req.Operation == admissionv1beta1.Create {
+		instances := &operatorv1alpha1.IBMLicensingList{}
+		if err := v.Client.List(ctx, instances); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		for _, existing := range instances.Items {
+			if existing.GetName() != instance.GetName() {
+				validation.deny("only one IBMLicensing instance is supported, IBMLicensing " + existing.GetName() + " already exists")
+			}
+		}
+	}
+
+	capabilities := v.Capabilities.Get()
+	spec := &instance.Spec
+	if spec.IsRouteEnabled() && !capabilities.RouteAPI {
+		// updates of instances that already enable route are not blocked, e.g. when Route API was removed from the cluster
+		routeEnabledBefore := false
+		if req.Operation == admissionv1beta1.Update {
+			oldInstance := &operatorv1alpha1.IBMLicensing{}
+			if err := v.decoder.DecodeRaw(req.OldObject, oldInstance); err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			routeEnabledBefore = oldInstance.Spec.IsRouteEnabled()
+		}
+		if routeEnabledBefore {
+			validation.warn("spec.routeEnabled is true, but Route API is not available on this cluster, route is not created, " +
+				"use spec.ingressEnabled instead")
+		} else {
+			validation.deny("spec.routeEnabled can not be true, Route API is not available on this cluster, use spec.ingressEnabled instead")
+		}
+	}
+	if spec.Sender != nil && spec.Sender.ReporterURL != "" && spec.Sender.ReporterSecretToken == "" {
+		validation.deny("spec.sender.reporterSecretToken is required when spec.sender.reporterURL is set")
+	}
+	validation.validateContainer("spec", spec.Container)
+	if spec.UsageEnabled {
+		validation.validateContainer("spec.usageContainer", spec.UsageContainer)
+	}
+
+	instanceNamespace := spec.InstanceNamespace
+	if instanceNamespace == "" {
+		instanceNamespace = v.OperatorNamespace
+	}
+	switch spec.HTTPSCertsSource {
+	case operatorv1alpha1.CustomCertsSource:
+		if err := validation.warnIfSecretMissing(ctx, v.Client, service.LicenseServiceCustomCertName, instanceNamespace,
+			"spec.httpsCertsSource is custom"); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	case operatorv1alpha1.OcpCertsSource:
+		if !capabilities.ServiceCAAPI {
+			validation.warn("spec.httpsCertsSource is ocp, but OpenShift service CA is not available on this cluster, " +
+				"License Service will not have certificate")
+		}
+	case operatorv1alpha1.CertManagerCertsSource:
+		validation.validateCertManager(capabilities, spec.CertManagerIssuer)
+	}
+	if spec.Sender != nil && spec.Sender.ReporterSecretToken != "" {
+		if err := validation.warnIfSecretMissing(ctx, v.Client, spec.Sender.ReporterSecretToken, instanceNamespace,
+			"spec.sender.reporterSecretToken is set"); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+	if spec.TokenRotation != nil {
+		validation.validateTokenRotation(spec.TokenRotation)
+	}
+
+	return validation.response()
+}
+
+// InjectDecoder injects the decoder into IBMLicensingValidator
+func (v *IBMLicensingValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:path=/mutate-operator-ibm-com-v1alpha1-ibmlicensing,mutating=true,failurePolicy=ignore,groups=operator.ibm.com,resources=ibmlicensings,verbs=create;update,versions=v1alpha1,matchPolicy=Equivalent,name=mibmlicensing.operator.ibm.com,sideEffects=None
+
+// IBMLicensingDefaulter persists default values of IBMLicensing, so that deployed configuration is visible in the instance
+type IBMLicensingDefaulter struct {
+	Log               logr.Logger
+	OperatorNamespace string
+	Capabilities      *CapabilityDetector
+	decoder           *admission.Decoder
+}
+
+// blank assignment to verify that IBMLicensingDefaulter implements admission.Handler
+var _ admission.Handler = &IBMLicensingDefaulter{}
+
+// Handle fills default values of IBMLicensing according to defaults policy annotation
+func (d *IBMLicensingDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	instance := &operatorv1alpha1.IBMLicensing{}
+	if err := d.decoder.Decode(req, instance); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	capabilities := d.Capabilities.Get()
+	err := instance.Spec.FillPersistentDefaultValues(operatorv1alpha1.IsPinningDefaults(instance),
+		capabilities.ServiceCAAPI, capabilities.RouteAPI, res.DefaultRHMPEnabled, d.OperatorNamespace)
+	if err != nil {
+		d.Log.Error(err, "Failed to fill default values of IBMLicensing")
+		return defaultsNotPersistedResponse(err)
+	}
+	return patchResponse(req, instance)
+}
+
+// InjectDecoder injects the decoder into IBMLicensingDefaulter
+func (d *IBMLicensingDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	d.decoder = decoder
+	return nil
+}
+
+// defaultsNotPersistedResponse allows admission when defaults can not be filled, operator fills them during reconciliation
+func defaultsNotPersistedResponse(err error) admission.Response {
+	response := admission.Allowed("")
+	response.Warnings = []string{"default values were not persisted: " + err.Error()}
+	return response
+}
+
+func patchResponse(req admission.Request, instance interface{}) admission.Response {
+	marshaledInstance, err := json.Marshal(instance)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledInstance)
+}
+
+// webhookValidation collects reasons to deny admission and warnings returned to the user
+type webhookValidation struct {
+	denials  []string
+	warnings []string
+}
+
+func (validation *webhookValidation) deny(message string) {
+	validation.denials = append(validation.denials, message)
+}
+
+func (validation *webhookValidation) warn(message string) {
+	validation.warnings = append(validation.warnings, message)
+}
+
+// validateContainer checks that image overrides can be combined with image from operator deployment
+func (validation *webhookValidation) validateContainer(fieldPath string, container operatorv1alpha1.Container) {
+	if container.ImageTagPostfix != "" && container.ImageName == "" {
+		validation.deny(fieldPath + ".imageTagPostfix can not be set without " + fieldPath + ".imageName")
+	}
+}
+
+// validateCertManager checks that certificates can be issued by cert-manager, cert-manager can be installed after the instance
+func (validation *webhookValidation) validateCertManager(capabilities res.Capabilities, issuer *operatorv1alpha1.CertManagerIssuer) {
+	if issuer == nil || issuer.Name == "" {
+		validation.deny("spec.certManagerIssuer.name is required when spec.httpsCertsSource is cert-manager")
+	}
+	if !capabilities.CertManagerAPI {
+		validation.warn("spec.httpsCertsSource is cert-manager, but cert-manager is not available on this cluster, " +
+			"certificates will be issued after cert-manager is installed")
+	}
+}
+
+// validateTokenRotation checks that the previous token is retired before the next rotation
+func (validation *webhookValidation) validateTokenRotation(rotation *operatorv1alpha1.IBMLicensingTokenRotation) {
+	if rotation.Interval.Duration <= 0 {
+		validation.deny("spec.tokenRotation.interval must be positive")
+		return
+	}
+	if gracePeriod := rotation.GetGracePeriod(); gracePeriod < 0 || gracePeriod >= rotation.Interval.Duration {
+		validation.deny("spec.tokenRotation.gracePeriod must not be negative and must be shorter than spec.tokenRotation.interval")
+	}
+}
+
+func (validation *webhookValidation) warnIfSecretMissing(ctx context.Context, c client.Client, name, namespace, reason string) error {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		validation.warn(reason + ", but secret " + name + " does not exist in " + namespace + " namespace yet")
+		return nil
+	}
+	return err
+}
+
+func (validation *webhookValidation) response() admission.Response {
+	var response admission.Response
+	if len(validation.denials) > 0 {
+		response = admission.Denied(strings.Join(validation.denials, "; "))
+	} else {
+		response = admission.Allowed("")
+	}
+	response.Warnings = validation.warnings
+	return response
+}
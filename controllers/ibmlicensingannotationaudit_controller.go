@@ -0,0 +1,322 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// maxAuditFindings limits number of pods listed in IBMLicensingAnnotationAudit status
+const maxAuditFindings = 50
+
+const reasonInvalidLicensingAnnotations = "InvalidLicensingAnnotations"
+
+// auditDebounceDelay delays audits triggered by changes of pods, namespaces and IBMLicensingMetadata, so that
+// all changes made during the delay, e.g. rollout of a deployment, are audited together
+const auditDebounceDelay = 10 * time.Second
+
+func (r *IBMLicensingAnnotationAuditReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.ClusterCache == nil {
+		r.ClusterCache = mgr.GetCache()
+	}
+	toAudits := &debouncedAuditsHandler{toRequests: r.allAudits, delay: auditDebounceDelay}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.IBMLicensingAnnotationAudit{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(source.NewKindWithCache(&corev1.Pod{}, r.ClusterCache), toAudits,
+			builder.WithPredicates(metadataChangedPredicate())).
+		Watches(source.NewKindWithCache(&corev1.Namespace{}, r.ClusterCache), toAudits,
+			builder.WithPredicates(metadataChangedPredicate())).
+		Watches(source.NewKindWithCache(&operatorv1alpha1.IBMLicensingMetadata{}, r.ClusterCache), toAudits,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// blank assignment to verify that IBMLicensingAnnotationAuditReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &IBMLicensingAnnotationAuditReconciler{}
+
+// IBMLicensingAnnotationAuditReconciler reconciles a IBMLicensingAnnotationAudit object
+type IBMLicensingAnnotationAuditReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client.Client
+	// ClusterCache watches and reads pods, namespaces and IBMLicensingMetadata in all namespaces, as the manager cache
+	// is limited to the operator namespace, the manager cache is used when not set
+	ClusterCache cache.Cache
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+
+	// reportedProblems keeps problems already reported with events per audit and pod, so that events are emitted
+	// only when problems of the pod change
+	reportedProblems     map[string]map[types.UID]string
+	reportedProblemsLock sync.Mutex
+}
+
+// +kubebuilder:rbac:groups=operator.ibm.com,resources=ibmlicensingannotationaudits;ibmlicensingannotationaudits/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile audits licensing annotations of pods, including annotations extended by IBMLicensingMetadata,
+// emits Warning events on pods with invalid annotations and summarizes findings in IBMLicensingAnnotationAudit status
+func (r *IBMLicensingAnnotationAuditReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("Request", req)
+	reqLogger.Info("Reconciling IBMLicensingAnnotationAudit")
+
+	instance := &operatorv1alpha1.IBMLicensingAnnotationAudit{}
+	if err := r.Client.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			r.setReportedProblems(req.Name, nil)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	status := instance.Status.DeepCopy()
+	findings, licensedPods, err := r.auditPods(instance)
+	if err != nil {
+		if _, isSelectorError := err.(selectorError); isSelectorError {
+			operatorv1alpha1.SetDegradedConditions(&status.Conditions, instance.GetGeneration(),
+				operatorv1alpha1.ReasonInvalidConfiguration, err.Error())
+			return reconcile.Result{}, r.updateAuditStatus(instance, status)
+		}
+		reqLogger.Error(err, "Failed to audit pods")
+		return reconcile.Result{}, err
+	}
+
+	status.LicensedPods = licensedPods
+	status.InvalidPods = int32(len(findings))
+	if len(findings) > maxAuditFindings {
+		status.Findings = findings[:maxAuditFindings]
+	} else {
+		status.Findings = findings
+	}
+	operatorv1alpha1.SetAvailableConditions(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ReasonAvailable,
+		"Licensing annotations of pods are audited")
+	if len(findings) == 0 {
+		operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionValid, metav1.ConditionTrue,
+			operatorv1alpha1.ReasonAnnotationsValid, "All audited pods have valid licensing annotations")
+	} else {
+		operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionValid, metav1.ConditionFalse,
+			reasonInvalidLicensingAnnotations, fmt.Sprintf("%d of %d audited pods have invalid licensing annotations", len(findings), licensedPods))
+	}
+	return reconcile.Result{}, r.updateAuditStatus(instance, status)
+}
+
+// selectorError is returned when namespaceSelector of IBMLicensingAnnotationAudit is invalid
+type selectorError struct {
+	error
+}
+
+// auditPods returns findings sorted by namespace and name and number of pods with licensing annotations
+func (r *IBMLicensingAnnotationAuditReconciler) auditPods(
+	instance *operatorv1alpha1.IBMLicensingAnnotationAudit) ([]operatorv1alpha1.IBMLicensingAnnotationAuditFinding, int32, error) {
+	namespaceSelector := labels.Everything()
+	if instance.Spec.NamespaceSelector != nil {
+		var err error
+		namespaceSelector, err = metav1.LabelSelectorAsSelector(instance.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, 0, selectorError{fmt.Errorf("invalid namespaceSelector: %w", err)}
+		}
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.ClusterCache.List(context.TODO(), namespaceList); err != nil {
+		return nil, 0, err
+	}
+	namespaces := map[string]*corev1.Namespace{}
+	for i := range namespaceList.Items {
+		namespaces[namespaceList.Items[i].GetName()] = &namespaceList.Items[i]
+	}
+	metadataList := &operatorv1alpha1.IBMLicensingMetadataList{}
+	if err := r.ClusterCache.List(context.TODO(), metadataList); err != nil {
+		return nil, 0, err
+	}
+	podList := &corev1.PodList{}
+	if err := r.ClusterCache.List(context.TODO(), podList); err != nil {
+		return nil, 0, err
+	}
+
+	var findings []operatorv1alpha1.IBMLicensingAnnotationAuditFinding
+	var licensedPods int32
+	reportedProblems := map[types.UID]string{}
+	previousProblems := r.getReportedProblems(instance.GetName())
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		namespace, ok := namespaces[pod.GetNamespace()]
+		if !ok || !namespaceSelector.Matches(labels.Set(namespace.GetLabels())) {
+			continue
+		}
+		annotations := getEffectiveLicensingAnnotations(pod, filterMatchingMetadata(metadataList.Items, pod, namespace))
+		if !operatorv1alpha1.HasLicensingAnnotations(annotations) {
+			continue
+		}
+		licensedPods++
+
+		var containerNames []string
+		for _, container := range pod.Spec.Containers {
+			containerNames = append(containerNames, container.Name)
+		}
+		problems := operatorv1alpha1.ValidatePodLicensingAnnotations(annotations, containerNames)
+		if len(problems) == 0 {
+			continue
+		}
+		findings = append(findings, operatorv1alpha1.IBMLicensingAnnotationAuditFinding{
+			Namespace: pod.GetNamespace(),
+			Pod:       pod.GetName(),
+			Problems:  problems,
+		})
+
+		message := "Invalid licensing annotations: " + strings.Join(problems, "; ")
+		reportedProblems[pod.GetUID()] = message
+		if !instance.Spec.DisableEvents && previousProblems[pod.GetUID()] != message {
+			r.Recorder.Event(pod, corev1.EventTypeWarning, reasonInvalidLicensingAnnotations, message)
+		}
+	}
+	r.setReportedProblems(instance.GetName(), reportedProblems)
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Namespace != findings[j].Namespace {
+			return findings[i].Namespace < findings[j].Namespace
+		}
+		return findings[i].Pod < findings[j].Pod
+	})
+	return findings, licensedPods, nil
+}
+
+// getEffectiveLicensingAnnotations returns pod annotations extended with annotations of matching IBMLicensingMetadata,
+// pod annotations and annotations of IBMLicensingMetadata with higher precedence are not overwritten
+func getEffectiveLicensingAnnotations(pod *corev1.Pod, metadataList []*operatorv1alpha1.IBMLicensingMetadata) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range pod.GetAnnotations() {
+		annotations[key] = value
+	}
+	for _, metadata := range metadataList {
+		for key, value := range metadata.Spec.Extend {
+			if _, ok := annotations[key]; !ok {
+				annotations[key] = value
+			}
+		}
+	}
+	return annotations
+}
+
+func (r *IBMLicensingAnnotationAuditReconciler) getReportedProblems(auditName string) map[types.UID]string {
+	r.reportedProblemsLock.Lock()
+	defer r.reportedProblemsLock.Unlock()
+	return r.reportedProblems[auditName]
+}
+
+func (r *IBMLicensingAnnotationAuditReconciler) setReportedProblems(auditName string, problems map[types.UID]string) {
+	r.reportedProblemsLock.Lock()
+	defer r.reportedProblemsLock.Unlock()
+	if r.reportedProblems == nil {
+		r.reportedProblems = map[string]map[types.UID]string{}
+	}
+	if problems == nil {
+		delete(r.reportedProblems, auditName)
+		return
+	}
+	r.reportedProblems[auditName] = problems
+}
+
+func (r *IBMLicensingAnnotationAuditReconciler) updateAuditStatus(instance *operatorv1alpha1.IBMLicensingAnnotationAudit,
+	status *operatorv1alpha1.IBMLicensingAnnotationAuditStatus) error {
+	status.ObservedGeneration = instance.GetGeneration()
+	// audit time is not compared, so that status is not updated when results of the audit are the same
+	status.LastAuditTime = instance.Status.LastAuditTime
+	if reflect.DeepEqual(instance.Status, *status) && status.LastAuditTime != nil {
+		return nil
+	}
+	now := metav1.Now()
+	status.LastAuditTime = &now
+	instance.Status = *status
+	if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+		r.Log.Error(err, "Failed to update IBMLicensingAnnotationAudit status")
+		return err
+	}
+	return nil
+}
+
+// allAudits enqueues all IBMLicensingAnnotationAudit
+func (r *IBMLicensingAnnotationAuditReconciler) allAudits(object handler.MapObject) []reconcile.Request {
+	auditList := &operatorv1alpha1.IBMLicensingAnnotationAuditList{}
+	if err := r.Client.List(context.TODO(), auditList); err != nil {
+		r.Log.Error(err, "Failed to list IBMLicensingAnnotationAudit")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(auditList.Items))
+	for _, audit := range auditList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: audit.GetName()}})
+	}
+	return requests
+}
+
+// debouncedAuditsHandler enqueues requests returned by toRequests after the delay, requests already waiting
+// in the queue are not added again, so that a burst of events results in a single audit
+type debouncedAuditsHandler struct {
+	toRequests func(handler.MapObject) []reconcile.Request
+	delay      time.Duration
+}
+
+var _ handler.EventHandler = &debouncedAuditsHandler{}
+
+func (h *debouncedAuditsHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(handler.MapObject{Meta: evt.Meta, Object: evt.Object}, q)
+}
+
+func (h *debouncedAuditsHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(handler.MapObject{Meta: evt.MetaNew, Object: evt.ObjectNew}, q)
+}
+
+func (h *debouncedAuditsHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(handler.MapObject{Meta: evt.Meta, Object: evt.Object}, q)
+}
+
+func (h *debouncedAuditsHandler) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(handler.MapObject{Meta: evt.Meta, Object: evt.Object}, q)
+}
+
+func (h *debouncedAuditsHandler) enqueue(object handler.MapObject, q workqueue.RateLimitingInterface) {
+	for _, req := range h.toRequests(object) {
+		q.AddAfter(req, h.delay)
+	}
+}
@@ -0,0 +1,60 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDebouncedAuditsHandlerAuditsBurstOfEventsOnce(t *testing.T) {
+	audit := reconcile.Request{NamespacedName: types.NamespacedName{Name: "audit"}}
+	h := &debouncedAuditsHandler{
+		toRequests: func(handler.MapObject) []reconcile.Request { return []reconcile.Request{audit} },
+		delay:      100 * time.Millisecond,
+	}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	for i := 0; i < 10; i++ {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "app"}}
+		h.Update(event.UpdateEvent{MetaOld: pod, ObjectOld: pod, MetaNew: pod, ObjectNew: pod}, q)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("expected audit to be delayed, got %d queued requests", q.Len())
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for q.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if q.Len() != 1 {
+		t.Fatalf("expected single audit request, got %d", q.Len())
+	}
+	if item, _ := q.Get(); item != audit {
+		t.Errorf("expected %v, got %v", audit, item)
+	}
+}
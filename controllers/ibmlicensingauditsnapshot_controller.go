@@ -0,0 +1,390 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// maxAuditSnapshotRuns limits number of runs listed in IBMLicensingAuditSnapshot status
+const maxAuditSnapshotRuns = 10
+
+// operatorContainerName is the name of operator container, which image is used by audit snapshot jobs
+const operatorContainerName = "ibm-licensing-operator"
+
+func (r *IBMLicensingAuditSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.IBMLicensingAuditSnapshot{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&batchv1.Job{}).
+		Watches(&source.Kind{Type: &operatorv1alpha1.IBMLicensing{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.mapLicensing)}).
+		Complete(r)
+}
+
+// mapLicensing maps IBMLicensing to IBMLicensingAuditSnapshots retrieving audit snapshots from it
+func (r *IBMLicensingAuditSnapshotReconciler) mapLicensing(object handler.MapObject) []reconcile.Request {
+	snapshots := &operatorv1alpha1.IBMLicensingAuditSnapshotList{}
+	if err := r.Client.List(context.TODO(), snapshots); err != nil {
+		r.Log.Error(err, "Failed to list IBMLicensingAuditSnapshot instances")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, instance := range snapshots.Items {
+		if instance.Spec.GetLicensingName() == object.Meta.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}})
+		}
+	}
+	return requests
+}
+
+// blank assignment to verify that IBMLicensingAuditSnapshotReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &IBMLicensingAuditSnapshotReconciler{}
+
+// IBMLicensingAuditSnapshotReconciler reconciles a IBMLicensingAuditSnapshot object
+type IBMLicensingAuditSnapshotReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client.Client
+	client.Reader
+	Log               logr.Logger
+	Scheme            *runtime.Scheme
+	Recorder          record.EventRecorder
+	OperatorNamespace string
+	Capabilities      *CapabilityDetector
+	// Image of audit snapshot jobs, when empty the image of the operator pod is used
+	Image string
+
+	imageLock sync.Mutex
+}
+
+// +kubebuilder:rbac:groups=operator.ibm.com,resources=ibmlicensingauditsnapshots;ibmlicensingauditsnapshots/status;ibmlicensingauditsnapshots/finalizers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:namespace=ibm-common-services,groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile records results of finished audit snapshot jobs in IBMLicensingAuditSnapshot status and creates a job when
+// the next run is due according to the schedule
+func (r *IBMLicensingAuditSnapshotReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("Request", req)
+	reqLogger.Info("Reconciling IBMLicensingAuditSnapshot")
+
+	instance := &operatorv1alpha1.IBMLicensingAuditSnapshot{}
+	if err := r.Client.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	status := instance.Status.DeepCopy()
+	generation := instance.GetGeneration()
+
+	finishedJobs, err := r.recordFinishedJobs(instance, status)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	schedule, licensing, err := r.validate(instance)
+	if err != nil {
+		reason := operatorv1alpha1.ReasonInvalidConfiguration
+		if errors.IsNotFound(err) {
+			reason = operatorv1alpha1.ReasonLicensingNotFound
+		} else if _, isConfigError := err.(configurationError); !isConfigError {
+			return reconcile.Result{}, err
+		}
+		operatorv1alpha1.SetDegradedConditions(&status.Conditions, generation, reason, err.Error())
+		return reconcile.Result{}, r.updateSnapshotStatus(instance, status, finishedJobs)
+	}
+
+	now := time.Now()
+	if scheduleTime := getMissedScheduleTime(schedule, instance, now); !scheduleTime.IsZero() && !instance.Spec.Suspend &&
+		status.Active == "" {
+		job, err := r.createJob(instance, licensing, scheduleTime)
+		if err != nil {
+			operatorv1alpha1.SetDegradedConditions(&status.Conditions, generation, "JobCreationFailed", err.Error())
+			if statusErr := r.updateSnapshotStatus(instance, status, finishedJobs); statusErr != nil {
+				return reconcile.Result{}, statusErr
+			}
+			return reconcile.Result{}, err
+		}
+		status.Active = job.GetName()
+		status.LastScheduleTime = &metav1.Time{Time: scheduleTime}
+	}
+
+	nextTime := schedule.Next(now)
+	if instance.Spec.Suspend {
+		operatorv1alpha1.SetAvailableConditions(&status.Conditions, generation, operatorv1alpha1.ReasonSnapshotsSuspended,
+			"Audit snapshots are suspended")
+	} else {
+		operatorv1alpha1.SetAvailableConditions(&status.Conditions, generation, operatorv1alpha1.ReasonSnapshotsScheduled,
+			"Next audit snapshot is scheduled at "+nextTime.UTC().Format(time.RFC3339))
+	}
+	if err := r.updateSnapshotStatus(instance, status, finishedJobs); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: nextTime.Sub(now)}, nil
+}
+
+// configurationError is returned when spec of IBMLicensingAuditSnapshot is invalid
+type configurationError struct {
+	error
+}
+
+// validate parses the schedule and returns IBMLicensing with filled default values, from which audit snapshots are retrieved
+func (r *IBMLicensingAuditSnapshotReconciler) validate(
+	instance *operatorv1alpha1.IBMLicensingAuditSnapshot) (cron.Schedule, *operatorv1alpha1.IBMLicensing, error) {
+	schedule, err := cron.ParseStandard(instance.Spec.Schedule)
+	if err != nil {
+		return nil, nil, configurationError{fmt.Errorf("schedule is invalid: %w", err)}
+	}
+	if err := instance.Spec.ValidateStorageAndDates(); err != nil {
+		return nil, nil, configurationError{err}
+	}
+	if instance.Spec.SigningKeySecret != "" {
+		signingKey, err := res.GetSecretValue(r.Client, instance.Spec.SigningKeySecret, instance.GetNamespace(), snapshot.SigningKeyKey)
+		if err != nil {
+			return nil, nil, configurationError{fmt.Errorf("signing key secret %s can not be read: %w", instance.Spec.SigningKeySecret, err)}
+		}
+		if _, err := snapshot.ParsePrivateKey([]byte(signingKey)); err != nil {
+			return nil, nil, configurationError{fmt.Errorf("signing key secret %s is invalid: %w", instance.Spec.SigningKeySecret, err)}
+		}
+	}
+	licensing := &operatorv1alpha1.IBMLicensing{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.GetLicensingName()}, licensing); err != nil {
+		return nil, nil, err
+	}
+	capabilities := r.Capabilities.Get()
+	if err := licensing.Spec.FillDefaultValues(capabilities.ServiceCAAPI, capabilities.RouteAPI, res.DefaultRHMPEnabled,
+		r.OperatorNamespace); err != nil {
+		return nil, nil, configurationError{fmt.Errorf("IBMLicensing %s is invalid: %w", licensing.GetName(), err)}
+	}
+	if licensing.Spec.InstanceNamespace != instance.GetNamespace() {
+		return nil, nil, configurationError{fmt.Errorf("IBMLicensingAuditSnapshot must be created in %s namespace, where "+
+			"License Service of IBMLicensing %s and its API token are deployed", licensing.Spec.InstanceNamespace, licensing.GetName())}
+	}
+	return schedule, licensing, nil
+}
+
+// getMissedScheduleTime returns the latest schedule time since the last run, zero when no run is due
+func getMissedScheduleTime(schedule cron.Schedule, instance *operatorv1alpha1.IBMLicensingAuditSnapshot, now time.Time) time.Time {
+	last := instance.GetCreationTimestamp().Time
+	if instance.Status.LastScheduleTime != nil {
+		last = instance.Status.LastScheduleTime.Time
+	}
+	var missed time.Time
+	for next := schedule.Next(last); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		missed = next
+	}
+	return missed
+}
+
+func (r *IBMLicensingAuditSnapshotReconciler) createJob(instance *operatorv1alpha1.IBMLicensingAuditSnapshot,
+	licensing *operatorv1alpha1.IBMLicensing, scheduleTime time.Time) (*batchv1.Job, error) {
+	image, err := r.getImage()
+	if err != nil {
+		return nil, err
+	}
+	job := snapshot.GetJob(instance, licensing, image, scheduleTime)
+	if err := controllerutil.SetControllerReference(instance, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(context.TODO(), job); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	r.Log.Info("Created audit snapshot job", "Job", job.GetName(), "Namespace", job.GetNamespace())
+	return job, nil
+}
+
+// getImage returns image of audit snapshot jobs, which is the image of the operator pod unless set explicitly
+func (r *IBMLicensingAuditSnapshotReconciler) getImage() (string, error) {
+	r.imageLock.Lock()
+	defer r.imageLock.Unlock()
+	if r.Image != "" {
+		return r.Image, nil
+	}
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		return "", fmt.Errorf("POD_NAME is not set, image of audit snapshot jobs can not be found")
+	}
+	pod := &corev1.Pod{}
+	if err := r.Reader.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: r.OperatorNamespace}, pod); err != nil {
+		return "", err
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == operatorContainerName {
+			r.Image = container.Image
+			return r.Image, nil
+		}
+	}
+	return "", fmt.Errorf("container %s not found in operator pod %s", operatorContainerName, podName)
+}
+
+// recordFinishedJobs adds runs of finished jobs to status and sets active job, returns finished jobs, which should be
+// deleted after status is updated
+func (r *IBMLicensingAuditSnapshotReconciler) recordFinishedJobs(instance *operatorv1alpha1.IBMLicensingAuditSnapshot,
+	status *operatorv1alpha1.IBMLicensingAuditSnapshotStatus) ([]batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	if err := r.Client.List(context.TODO(), jobs, client.InNamespace(instance.GetNamespace()),
+		client.MatchingLabels(snapshot.LabelsForJob(instance))); err != nil {
+		r.Log.Error(err, "Failed to list audit snapshot jobs")
+		return nil, err
+	}
+	sort.Slice(jobs.Items, func(i, j int) bool {
+		return jobs.Items[i].GetName() < jobs.Items[j].GetName()
+	})
+
+	status.Active = ""
+	var finishedJobs []batchv1.Job
+	for _, job := range jobs.Items {
+		finished, succeeded := isJobFinished(&job)
+		if !finished {
+			status.Active = job.GetName()
+			continue
+		}
+		finishedJobs = append(finishedJobs, job)
+		if isRunRecorded(status, job.GetName()) {
+			continue
+		}
+		run, err := r.getRun(&job, succeeded)
+		if err != nil {
+			return nil, err
+		}
+		if run.Outcome == operatorv1alpha1.AuditSnapshotSucceeded {
+			status.LastSuccessfulTime = run.CompletionTime
+			message := fmt.Sprintf("Audit snapshot of %d bytes with SHA-256 checksum %s stored in %s", run.Size, run.Checksum, run.Location)
+			if run.Signature != "" {
+				message += ", signature stored in " + run.Signature
+			}
+			r.Recorder.Event(instance, corev1.EventTypeNormal, "AuditSnapshotStored", message)
+		} else {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "AuditSnapshotFailed", "Audit snapshot job "+
+				job.GetName()+" failed: "+run.Message)
+		}
+		status.Runs = append([]operatorv1alpha1.IBMLicensingAuditSnapshotRun{run}, status.Runs...)
+		if len(status.Runs) > maxAuditSnapshotRuns {
+			status.Runs = status.Runs[:maxAuditSnapshotRuns]
+		}
+	}
+	return finishedJobs, nil
+}
+
+func isJobFinished(job *batchv1.Job) (finished bool, succeeded bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, true
+		case batchv1.JobFailed:
+			return true, false
+		}
+	}
+	return false, false
+}
+
+func isRunRecorded(status *operatorv1alpha1.IBMLicensingAuditSnapshotStatus, jobName string) bool {
+	for _, run := range status.Runs {
+		if run.Job == jobName {
+			return true
+		}
+	}
+	return false
+}
+
+// getRun describes run of finished job using the result which the job passes in termination message of its pod
+func (r *IBMLicensingAuditSnapshotReconciler) getRun(job *batchv1.Job, succeeded bool) (operatorv1alpha1.IBMLicensingAuditSnapshotRun, error) {
+	run := operatorv1alpha1.IBMLicensingAuditSnapshotRun{Job: job.GetName(), CompletionTime: job.Status.CompletionTime}
+	if scheduleTime, err := time.Parse(time.RFC3339, job.GetAnnotations()[snapshot.ScheduleTimeAnnotation]); err == nil {
+		run.ScheduleTime = metav1.Time{Time: scheduleTime}
+	} else {
+		run.ScheduleTime = job.GetCreationTimestamp()
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			run.CompletionTime = &condition.LastTransitionTime
+			run.Message = condition.Message
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.Client.List(context.TODO(), pods, client.InNamespace(job.GetNamespace()),
+		client.MatchingLabels{"job-name": job.GetName()}); err != nil {
+		return run, err
+	}
+	result, ok := snapshot.GetJobResult(pods.Items)
+	run.Location = result.Location
+	run.Size = result.Size
+	run.Checksum = result.Checksum
+	run.Signature = result.Signature
+	if result.Error != "" {
+		run.Message = result.Error
+	}
+	if succeeded && ok && result.Location != "" {
+		run.Outcome = operatorv1alpha1.AuditSnapshotSucceeded
+	} else {
+		run.Outcome = operatorv1alpha1.AuditSnapshotFailed
+	}
+	return run, nil
+}
+
+// updateSnapshotStatus writes status if it changed and deletes finished jobs, which are already recorded in status
+func (r *IBMLicensingAuditSnapshotReconciler) updateSnapshotStatus(instance *operatorv1alpha1.IBMLicensingAuditSnapshot,
+	status *operatorv1alpha1.IBMLicensingAuditSnapshotStatus, finishedJobs []batchv1.Job) error {
+	status.ObservedGeneration = instance.GetGeneration()
+	if !reflect.DeepEqual(instance.Status, *status) {
+		instance.Status = *status
+		if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+			r.Log.Error(err, "Failed to update IBMLicensingAuditSnapshot status")
+			return err
+		}
+	}
+	propagation := metav1.DeletePropagationBackground
+	for i := range finishedJobs {
+		if err := r.Client.Delete(context.TODO(), &finishedJobs[i], &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil &&
+			!errors.IsNotFound(err) {
+			r.Log.Error(err, "Failed to delete audit snapshot job", "Job", finishedJobs[i].GetName())
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetMissedScheduleTime(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 2 1 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		lastScheduleTime *time.Time
+		now              time.Time
+		expected         time.Time
+	}{
+		{
+			name: "no run due since creation",
+			now:  time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "first run due since creation",
+			now:      time.Date(2021, 2, 1, 2, 0, 0, 0, time.UTC),
+			expected: time.Date(2021, 2, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "no run due since last run",
+			lastScheduleTime: timePtr(time.Date(2021, 2, 1, 2, 0, 0, 0, time.UTC)),
+			now:              time.Date(2021, 2, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "only the latest of missed runs",
+			lastScheduleTime: timePtr(time.Date(2021, 2, 1, 2, 0, 0, 0, time.UTC)),
+			now:              time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC),
+			expected:         time.Date(2021, 5, 1, 2, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &operatorv1alpha1.IBMLicensingAuditSnapshot{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			}
+			if test.lastScheduleTime != nil {
+				lastScheduleTime := metav1.NewTime(*test.lastScheduleTime)
+				instance.Status.LastScheduleTime = &lastScheduleTime
+			}
+			if missed := getMissedScheduleTime(schedule, instance, test.now); !missed.Equal(test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, missed)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestGetRun(t *testing.T) {
+	const namespace = "ibm-common-services"
+	scheduleTime := metav1.NewTime(time.Date(2021, 6, 1, 2, 0, 0, 0, time.UTC))
+	completionTime := metav1.NewTime(time.Date(2021, 6, 1, 2, 1, 0, 0, time.UTC))
+	failureTime := metav1.NewTime(time.Date(2021, 6, 1, 2, 5, 0, 0, time.UTC))
+	tests := []struct {
+		name               string
+		succeeded          bool
+		failed             bool
+		terminationMessage *string
+		expected           operatorv1alpha1.IBMLicensingAuditSnapshotRun
+	}{
+		{
+			name:      "succeeded",
+			succeeded: true,
+			terminationMessage: stringPtr(`{"location":"audit-snapshots:/snapshot-20210601-020000.zip","size":1024,` +
+				`"checksum":"abc","signature":"audit-snapshots:/snapshot-20210601-020000.zip.sig"}`),
+			expected: operatorv1alpha1.IBMLicensingAuditSnapshotRun{
+				Outcome:   operatorv1alpha1.AuditSnapshotSucceeded,
+				Location:  "audit-snapshots:/snapshot-20210601-020000.zip",
+				Size:      1024,
+				Checksum:  "abc",
+				Signature: "audit-snapshots:/snapshot-20210601-020000.zip.sig",
+			},
+		},
+		{
+			name:               "succeeded with pruning error",
+			succeeded:          true,
+			terminationMessage: stringPtr(`{"location":"audit-snapshots:/snapshot.zip","size":1,"error":"archive stored, but old archives were not deleted"}`),
+			expected: operatorv1alpha1.IBMLicensingAuditSnapshotRun{
+				Outcome:  operatorv1alpha1.AuditSnapshotSucceeded,
+				Location: "audit-snapshots:/snapshot.zip",
+				Size:     1,
+				Message:  "archive stored, but old archives were not deleted",
+			},
+		},
+		{
+			name:               "failed with result",
+			failed:             true,
+			terminationMessage: stringPtr(`{"error":"request failed with status 401 Unauthorized"}`),
+			expected: operatorv1alpha1.IBMLicensingAuditSnapshotRun{
+				Outcome: operatorv1alpha1.AuditSnapshotFailed,
+				Message: "request failed with status 401 Unauthorized",
+			},
+		},
+		{
+			name:               "failed without result",
+			failed:             true,
+			terminationMessage: stringPtr("OOMKilled\n"),
+			expected: operatorv1alpha1.IBMLicensingAuditSnapshotRun{
+				Outcome: operatorv1alpha1.AuditSnapshotFailed,
+				Message: "OOMKilled",
+			},
+		},
+		{
+			name:   "failed without pod",
+			failed: true,
+			expected: operatorv1alpha1.IBMLicensingAuditSnapshotRun{
+				Outcome: operatorv1alpha1.AuditSnapshotFailed,
+				Message: "Job has reached the specified backoff limit",
+			},
+		},
+		{
+			name:               "succeeded without location",
+			succeeded:          true,
+			terminationMessage: stringPtr("{}"),
+			expected:           operatorv1alpha1.IBMLicensingAuditSnapshotRun{Outcome: operatorv1alpha1.AuditSnapshotFailed},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "monthly-1622512800",
+					Namespace:   namespace,
+					Annotations: map[string]string{snapshot.ScheduleTimeAnnotation: scheduleTime.Format(time.RFC3339)},
+				},
+			}
+			expected := test.expected
+			expected.Job = job.GetName()
+			expected.ScheduleTime = scheduleTime
+			if test.succeeded {
+				job.Status.CompletionTime = &completionTime
+				expected.CompletionTime = &completionTime
+			}
+			if test.failed {
+				job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+					LastTransitionTime: failureTime, Message: "Job has reached the specified backoff limit"}}
+				expected.CompletionTime = &failureTime
+			}
+
+			var objects []runtime.Object
+			if test.terminationMessage != nil {
+				objects = append(objects, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: job.GetName() + "-abcde", Namespace: namespace,
+						Labels: map[string]string{"job-name": job.GetName()}},
+					Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "audit-snapshot",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							Message: *test.terminationMessage,
+						}},
+					}}},
+				})
+			}
+			r := &IBMLicensingAuditSnapshotReconciler{Client: fake.NewFakeClientWithScheme(scheme.Scheme, objects...)}
+
+			run, err := r.getRun(job, test.succeeded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(run, expected) {
+				t.Errorf("unexpected run: %s", diff.ObjectReflectDiff(expected, run))
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,314 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// maxMatchedPodNames limits number of pod names shown in IBMLicensingMetadata status
+const maxMatchedPodNames = 10
+
+// SetupWithManager watches IBMLicensingMetadata, pods and namespaces with the cluster cache, as IBMLicensingMetadata
+// are created in application namespaces, while the manager cache can be limited to the operator namespace
+func (r *IBMLicensingMetadataReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.ClusterCache == nil {
+		r.ClusterCache = mgr.GetCache()
+	}
+	c, err := controller.New("ibmlicensingmetadata", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	if err := c.Watch(source.NewKindWithCache(&operatorv1alpha1.IBMLicensingMetadata{}, r.ClusterCache),
+		&handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	if err := c.Watch(source.NewKindWithCache(&corev1.Pod{}, r.ClusterCache),
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.metadataForPod)},
+		metadataChangedPredicate()); err != nil {
+		return err
+	}
+	if err := c.Watch(source.NewKindWithCache(&operatorv1alpha1.IBMLicensingMetadata{}, r.ClusterCache),
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.metadataForMetadata)},
+		predicate.GenerationChangedPredicate{}); err != nil {
+		return err
+	}
+	return c.Watch(source.NewKindWithCache(&corev1.Namespace{}, r.ClusterCache),
+		&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.metadataForNamespace)},
+		metadataChangedPredicate())
+}
+
+// blank assignment to verify that IBMLicensingMetadataReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &IBMLicensingMetadataReconciler{}
+
+// IBMLicensingMetadataReconciler reconciles a IBMLicensingMetadata object
+type IBMLicensingMetadataReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client.Client
+	// ClusterCache watches and reads IBMLicensingMetadata, pods and namespaces in all namespaces, the manager cache
+	// is used when not set
+	ClusterCache cache.Cache
+	Log          logr.Logger
+	Scheme       *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=operator.ibm.com,resources=ibmlicensingmetadatas;ibmlicensingmetadatas/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods;namespaces,verbs=get;list;watch
+
+// Reconcile validates IBMLicensingMetadata annotations and reports pods matching its condition in status
+func (r *IBMLicensingMetadataReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("Request", req)
+	reqLogger.Info("Reconciling IBMLicensingMetadata")
+
+	instance := &operatorv1alpha1.IBMLicensingMetadata{}
+	if err := r.ClusterCache.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	status := instance.Status.DeepCopy()
+	status.ValidationErrors = instance.Spec.Validate()
+	if len(status.ValidationErrors) == 0 {
+		operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionValid,
+			metav1.ConditionTrue, operatorv1alpha1.ReasonAnnotationsValid, "Annotations conform to licensing annotations schema")
+	} else {
+		operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionValid,
+			metav1.ConditionFalse, operatorv1alpha1.ReasonInvalidConfiguration, strings.Join(status.ValidationErrors, "; "))
+	}
+
+	matchedPods, err := r.getMatchedPods(instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to find matching pods")
+		return reconcile.Result{}, err
+	}
+	var matchedPodNames []string
+	for pod := range matchedPods {
+		if pod.Namespace == instance.GetNamespace() {
+			matchedPodNames = append(matchedPodNames, pod.Name)
+		} else {
+			matchedPodNames = append(matchedPodNames, pod.String())
+		}
+	}
+	sort.Strings(matchedPodNames)
+	status.MatchedPods = int32(len(matchedPodNames))
+	if len(matchedPodNames) > maxMatchedPodNames {
+		matchedPodNames = matchedPodNames[:maxMatchedPodNames]
+	}
+	status.MatchedPodNames = matchedPodNames
+
+	if len(status.ValidationErrors) == 0 {
+		conflicts, err := r.getConflicts(instance, matchedPods)
+		if err != nil {
+			reqLogger.Error(err, "Failed to check overlapping IBMLicensingMetadata")
+			return reconcile.Result{}, err
+		}
+		if len(conflicts) == 0 {
+			operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionConflicting,
+				metav1.ConditionFalse, operatorv1alpha1.ReasonNoOverlappingMetadata, "No other IBMLicensingMetadata extends matched pods with different values")
+		} else {
+			operatorv1alpha1.SetCondition(&status.Conditions, instance.GetGeneration(), operatorv1alpha1.ConditionConflicting,
+				metav1.ConditionTrue, operatorv1alpha1.ReasonOverlappingMetadata, strings.Join(conflicts, "; "))
+		}
+	} else {
+		// invalid IBMLicensingMetadata is not applied, so it does not conflict with other ones
+		meta.RemoveStatusCondition(&status.Conditions, operatorv1alpha1.ConditionConflicting)
+	}
+	status.ObservedGeneration = instance.GetGeneration()
+
+	if reflect.DeepEqual(instance.Status, *status) {
+		return reconcile.Result{}, nil
+	}
+	reqLogger.Info("Updating IBMLicensingMetadata status")
+	instance.Status = *status
+	if err := r.Client.Status().Update(context.TODO(), instance); err != nil {
+		reqLogger.Error(err, "Failed to update IBMLicensingMetadata status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// getMatchedPods returns pods matching the condition of the instance
+func (r *IBMLicensingMetadataReconciler) getMatchedPods(instance *operatorv1alpha1.IBMLicensingMetadata) (map[types.NamespacedName]bool, error) {
+	// invalid selectors are reported in status as validation errors and match no pods
+	namespaceSelector, err := instance.Spec.Condition.GetNamespaceSelector()
+	if err != nil {
+		return nil, nil
+	}
+	podSelector, err := instance.Spec.Condition.GetPodSelector()
+	if err != nil {
+		return nil, nil
+	}
+
+	namespaces := []string{instance.GetNamespace()}
+	if namespaceSelector != nil {
+		namespaceList := &corev1.NamespaceList{}
+		if err := r.ClusterCache.List(context.TODO(), namespaceList, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+			return nil, err
+		}
+		namespaces = namespaces[:0]
+		for _, namespace := range namespaceList.Items {
+			namespaces = append(namespaces, namespace.GetName())
+		}
+	}
+
+	matchedPods := map[types.NamespacedName]bool{}
+	for _, namespace := range namespaces {
+		podList := &corev1.PodList{}
+		if err := r.ClusterCache.List(context.TODO(), podList, client.InNamespace(namespace),
+			client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+			return nil, err
+		}
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			matching, err := instance.Spec.Condition.IsMatching(pod)
+			if err != nil {
+				return nil, err
+			}
+			if matching {
+				matchedPods[types.NamespacedName{Namespace: namespace, Name: pod.GetName()}] = true
+			}
+		}
+	}
+	return matchedPods, nil
+}
+
+// getConflicts returns descriptions of valid IBMLicensingMetadata that match some of matchedPods and extend
+// the same annotations as the instance with different values
+func (r *IBMLicensingMetadataReconciler) getConflicts(instance *operatorv1alpha1.IBMLicensingMetadata,
+	matchedPods map[types.NamespacedName]bool) ([]string, error) {
+	if len(matchedPods) == 0 {
+		return nil, nil
+	}
+	metadataList := &operatorv1alpha1.IBMLicensingMetadataList{}
+	if err := r.ClusterCache.List(context.TODO(), metadataList); err != nil {
+		return nil, err
+	}
+	sort.Slice(metadataList.Items, func(i, j int) bool {
+		return metadataList.Items[i].HasPrecedenceOver(&metadataList.Items[j])
+	})
+
+	var conflicts []string
+	for i := range metadataList.Items {
+		other := &metadataList.Items[i]
+		if other.GetNamespace() == instance.GetNamespace() && other.GetName() == instance.GetName() {
+			continue
+		}
+		keys := instance.ConflictingKeys(other)
+		if len(keys) == 0 || len(other.Spec.Validate()) > 0 {
+			continue
+		}
+		otherMatchedPods, err := r.getMatchedPods(other)
+		if err != nil {
+			return nil, err
+		}
+		if !isOverlapping(matchedPods, otherMatchedPods) {
+			continue
+		}
+		winner := instance
+		if other.HasPrecedenceOver(instance) {
+			winner = other
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s/%s (priority %d) extends %s with different values, value from %s/%s is used",
+			other.GetNamespace(), other.GetName(), other.Spec.Priority, strings.Join(keys, ", "), winner.GetNamespace(), winner.GetName()))
+	}
+	return conflicts, nil
+}
+
+func isOverlapping(pods, otherPods map[types.NamespacedName]bool) bool {
+	for pod := range pods {
+		if otherPods[pod] {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataForPod enqueues IBMLicensingMetadata from namespace of the pod and all IBMLicensingMetadata with namespaceSelector
+func (r *IBMLicensingMetadataReconciler) metadataForPod(object handler.MapObject) []reconcile.Request {
+	return r.metadataRequests(func(metadata *operatorv1alpha1.IBMLicensingMetadata) bool {
+		return metadata.GetNamespace() == object.Meta.GetNamespace() || metadata.Spec.Condition.NamespaceSelector != nil
+	})
+}
+
+// metadataForMetadata enqueues other IBMLicensingMetadata, as change of one of them could resolve or cause conflicts
+func (r *IBMLicensingMetadataReconciler) metadataForMetadata(object handler.MapObject) []reconcile.Request {
+	return r.metadataRequests(func(metadata *operatorv1alpha1.IBMLicensingMetadata) bool {
+		return metadata.GetNamespace() != object.Meta.GetNamespace() || metadata.GetName() != object.Meta.GetName()
+	})
+}
+
+// metadataForNamespace enqueues all IBMLicensingMetadata with namespaceSelector, as namespace labels could change matching
+func (r *IBMLicensingMetadataReconciler) metadataForNamespace(object handler.MapObject) []reconcile.Request {
+	return r.metadataRequests(func(metadata *operatorv1alpha1.IBMLicensingMetadata) bool {
+		return metadata.Spec.Condition.NamespaceSelector != nil
+	})
+}
+
+func (r *IBMLicensingMetadataReconciler) metadataRequests(filter func(*operatorv1alpha1.IBMLicensingMetadata) bool) []reconcile.Request {
+	metadataList := &operatorv1alpha1.IBMLicensingMetadataList{}
+	if err := r.ClusterCache.List(context.TODO(), metadataList); err != nil {
+		r.Log.Error(err, "Failed to list IBMLicensingMetadata")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range metadataList.Items {
+		metadata := &metadataList.Items[i]
+		if !filter(metadata) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      metadata.GetName(),
+			Namespace: metadata.GetNamespace(),
+		}})
+	}
+	return requests
+}
+
+// metadataChangedPredicate skips updates that do not change labels or annotations, e.g. status updates
+func metadataChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.MetaOld.GetAnnotations(), e.MetaNew.GetAnnotations()) ||
+				!reflect.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels())
+		},
+	}
+}
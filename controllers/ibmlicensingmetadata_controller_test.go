@@ -0,0 +1,170 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeClusterCache serves reads of the cluster cache from fake client, informers are not supported
+type fakeClusterCache struct {
+	client.Reader
+}
+
+// blank assignment to verify that fakeClusterCache implements cache.Cache
+var _ cache.Cache = &fakeClusterCache{}
+
+func newFakeClusterCache(scheme *runtime.Scheme, objects ...runtime.Object) *fakeClusterCache {
+	return &fakeClusterCache{Reader: fake.NewFakeClientWithScheme(scheme, objects...)}
+}
+
+func (c *fakeClusterCache) GetInformer(context.Context, runtime.Object) (cache.Informer, error) {
+	return nil, errors.New("informers are not supported by fake cluster cache")
+}
+
+func (c *fakeClusterCache) GetInformerForKind(context.Context, schema.GroupVersionKind) (cache.Informer, error) {
+	return nil, errors.New("informers are not supported by fake cluster cache")
+}
+
+func (c *fakeClusterCache) Start(<-chan struct{}) error {
+	return nil
+}
+
+func (c *fakeClusterCache) WaitForCacheSync(<-chan struct{}) bool {
+	return true
+}
+
+func (c *fakeClusterCache) IndexField(context.Context, runtime.Object, string, client.IndexerFunc) error {
+	return nil
+}
+
+func TestGetMatchedPods(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "operator", Labels: map[string]string{"team": "ops"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app1", Labels: map[string]string{"licensed": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app2", Labels: map[string]string{"licensed": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "operator-pod", Namespace: "operator",
+			Labels: map[string]string{"app": "db"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app1",
+			Labels: map[string]string{"app": "db"}, Annotations: map[string]string{"productID": "id1"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app1",
+			Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app2",
+			Labels: map[string]string{"app": "db"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "other",
+			Labels: map[string]string{"app": "db"}}},
+	}
+	r := &IBMLicensingMetadataReconciler{
+		ClusterCache: newFakeClusterCache(scheme.Scheme, objects...),
+		Log:          logf.Log.WithName("test"),
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		condition operatorv1alpha1.IBMLicensingMetadataCondition
+		expected  []types.NamespacedName
+	}{
+		{
+			name:      "without selectors matches all pods from own namespace",
+			namespace: "app1",
+			expected:  []types.NamespacedName{{Namespace: "app1", Name: "db"}, {Namespace: "app1", Name: "web"}},
+		},
+		{
+			name:      "podSelector filters pods by labels",
+			namespace: "app1",
+			condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			expected: []types.NamespacedName{{Namespace: "app1", Name: "web"}},
+		},
+		{
+			name:      "annotation filters pods by annotations",
+			namespace: "app1",
+			condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+				Annotation: map[string]string{"productID": "id1"},
+			},
+			expected: []types.NamespacedName{{Namespace: "app1", Name: "db"}},
+		},
+		{
+			name:      "namespaceSelector matches pods outside of own namespace",
+			namespace: "operator",
+			condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"licensed": "true"}},
+			},
+			expected: []types.NamespacedName{{Namespace: "app1", Name: "db"}, {Namespace: "app2", Name: "db"}},
+		},
+		{
+			name:      "empty namespaceSelector matches pods from all namespaces",
+			namespace: "operator",
+			condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+			expected: []types.NamespacedName{{Namespace: "operator", Name: "operator-pod"}, {Namespace: "app1", Name: "db"},
+				{Namespace: "app2", Name: "db"}, {Namespace: "other", Name: "db"}},
+		},
+		{
+			name:      "invalid selector matches no pods",
+			namespace: "app1",
+			condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+				PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: "Unknown"},
+				}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &operatorv1alpha1.IBMLicensingMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: "metadata", Namespace: test.namespace},
+				Spec:       operatorv1alpha1.IBMLicensingMetadataSpec{Condition: test.condition},
+			}
+			matchedPods, err := r.getMatchedPods(instance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := map[types.NamespacedName]bool{}
+			for _, pod := range test.expected {
+				expected[pod] = true
+			}
+			if len(matchedPods) == 0 && len(expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(matchedPods, expected) {
+				t.Errorf("expected matched pods %v, got %v", expected, matchedPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// PodMetadataWebhookPath is the path on which pod mutating webhook is served
+const PodMetadataWebhookPath = "/mutate-v1-pod"
+
+// AppliedMetadataAnnotation lists IBMLicensingMetadata objects that extended pod annotations
+const AppliedMetadataAnnotation = "operator.ibm.com/licensing-metadata"
+
+// +kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=ignore,groups="",resources=pods,verbs=create,versions=v1,name=mpod.operator.ibm.com,sideEffects=NoneOnDryRun
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// PodMetadataMutator merges extend annotations of matching IBMLicensingMetadata into pods on admission
+type PodMetadataMutator struct {
+	// ClusterCache reads IBMLicensingMetadata and namespaces of all namespaces, as pods are created in application
+	// namespaces, while the manager cache can be limited to the operator namespace
+	ClusterCache cache.Cache
+	Log          logr.Logger
+	Recorder     record.EventRecorder
+	decoder      *admission.Decoder
+}
+
+// blank assignment to verify that PodMetadataMutator implements admission.Handler
+var _ admission.Handler = &PodMetadataMutator{}
+
+// Handle applies IBMLicensingMetadata extend annotations to the pod, according to conflict policy and dry run mode
+func (m *PodMetadataMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+	if err := m.decoder.Decode(req, pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// namespace of created pod is not always set in the object
+	pod.SetNamespace(req.Namespace)
+	podName := pod.GetName()
+	if podName == "" {
+		podName = pod.GetGenerateName() + "*"
+	}
+	reqLogger := m.Log.WithValues("pod", req.Namespace+"/"+podName)
+	recorder := m.Recorder
+	if req.DryRun != nil && *req.DryRun {
+		// events are side effects, which are not allowed for API server dry run requests
+		recorder = noopEventRecorder{}
+	}
+
+	metadataList, err := m.matchingMetadata(ctx, pod)
+	if err != nil {
+		reqLogger.Error(err, "Failed to find matching IBMLicensingMetadata")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(metadataList) == 0 {
+		return admission.Allowed("no IBMLicensingMetadata matches the pod")
+	}
+
+	annotations := map[string]string{}
+	for key, value := range pod.GetAnnotations() {
+		annotations[key] = value
+	}
+	// keys set by IBMLicensingMetadata with higher precedence are not changed by following ones
+	appliedKeys := map[string]bool{}
+	var appliedMetadata []string
+	for _, metadata := range metadataList {
+		changes := map[string]string{}
+		var keptKeys []string
+		for _, key := range sortedKeys(metadata.Spec.Extend) {
+			value := metadata.Spec.Extend[key]
+			if appliedKeys[key] {
+				continue
+			}
+			if podValue, ok := pod.GetAnnotations()[key]; ok && podValue != value {
+				switch metadata.Spec.GetConflictPolicy() {
+				case operatorv1alpha1.ConflictPolicyReject:
+					message := fmt.Sprintf("pod %s has annotation %s=%q, which conflicts with value %q from IBMLicensingMetadata %s/%s",
+						podName, key, podValue, value, metadata.GetNamespace(), metadata.GetName())
+					recorder.Event(metadata, corev1.EventTypeWarning, "AnnotationConflict", message)
+					if metadata.Spec.DryRun {
+						continue
+					}
+					return admission.Denied(message)
+				case operatorv1alpha1.ConflictPolicyKeep:
+					recorder.Eventf(metadata, corev1.EventTypeWarning, "AnnotationConflict",
+						"Pod %s/%s keeps annotation %s=%q instead of %q", req.Namespace, podName, key, podValue, value)
+					keptKeys = append(keptKeys, key)
+					continue
+				}
+			}
+			changes[key] = value
+		}
+		if !metadata.Spec.DryRun {
+			// value kept by this IBMLicensingMetadata cannot be overwritten by ones with lower precedence
+			for _, key := range keptKeys {
+				appliedKeys[key] = true
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if metadata.Spec.DryRun {
+			recorder.Eventf(metadata, corev1.EventTypeNormal, "DryRun", "Pod %s/%s would be annotated with %s",
+				req.Namespace, podName, formatAnnotations(changes))
+			continue
+		}
+		for key, value := range changes {
+			annotations[key] = value
+			appliedKeys[key] = true
+		}
+		appliedMetadata = append(appliedMetadata, metadata.GetNamespace()+"/"+metadata.GetName())
+	}
+	if len(appliedMetadata) == 0 {
+		return admission.Allowed("no annotations applied")
+	}
+
+	annotations[AppliedMetadataAnnotation] = strings.Join(appliedMetadata, ",")
+	pod.SetAnnotations(annotations)
+	marshaledPod, err := json.Marshal(pod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	reqLogger.Info("Applying licensing annotations", "metadata", appliedMetadata)
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledPod)
+}
+
+// noopEventRecorder drops all events, it is used when events must not be emitted
+type noopEventRecorder struct{}
+
+// blank assignment to verify that noopEventRecorder implements record.EventRecorder
+var _ record.EventRecorder = noopEventRecorder{}
+
+func (noopEventRecorder) Event(runtime.Object, string, string, string) {}
+
+func (noopEventRecorder) Eventf(runtime.Object, string, string, string, ...interface{}) {}
+
+func (noopEventRecorder) AnnotatedEventf(runtime.Object, map[string]string, string, string, string, ...interface{}) {
+}
+
+// InjectDecoder injects the decoder into PodMetadataMutator
+func (m *PodMetadataMutator) InjectDecoder(d *admission.Decoder) error {
+	m.decoder = d
+	return nil
+}
+
+// matchingMetadata returns valid IBMLicensingMetadata matching the pod, sorted by precedence
+func (m *PodMetadataMutator) matchingMetadata(ctx context.Context, pod *corev1.Pod) ([]*operatorv1alpha1.IBMLicensingMetadata, error) {
+	metadataList := &operatorv1alpha1.IBMLicensingMetadataList{}
+	if err := m.ClusterCache.List(ctx, metadataList); err != nil {
+		return nil, err
+	}
+	namespace := &corev1.Namespace{}
+	if err := m.ClusterCache.Get(ctx, types.NamespacedName{Name: pod.GetNamespace()}, namespace); err != nil {
+		return nil, err
+	}
+	return filterMatchingMetadata(metadataList.Items, pod, namespace), nil
+}
+
+// filterMatchingMetadata returns valid IBMLicensingMetadata matching the pod from given namespace, sorted by precedence
+func filterMatchingMetadata(metadataList []operatorv1alpha1.IBMLicensingMetadata, pod *corev1.Pod,
+	namespace *corev1.Namespace) []*operatorv1alpha1.IBMLicensingMetadata {
+	var matching []*operatorv1alpha1.IBMLicensingMetadata
+	for i := range metadataList {
+		metadata := &metadataList[i]
+		if len(metadata.Spec.Validate()) > 0 {
+			continue
+		}
+		namespaceMatching, err := metadata.Spec.Condition.IsNamespaceMatching(metadata.GetNamespace(), namespace)
+		if err != nil || !namespaceMatching {
+			continue
+		}
+		podMatching, err := metadata.Spec.Condition.IsMatching(pod)
+		if err != nil || !podMatching {
+			continue
+		}
+		matching = append(matching, metadata)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].HasPrecedenceOver(matching[j])
+	})
+	return matching
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatAnnotations(annotations map[string]string) string {
+	formatted := make([]string, 0, len(annotations))
+	for _, key := range sortedKeys(annotations) {
+		formatted = append(formatted, fmt.Sprintf("%s=%q", key, annotations[key]))
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,167 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var webPodCondition = operatorv1alpha1.IBMLicensingMetadataCondition{
+	PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+}
+
+func newTestPodMetadataMutator(t *testing.T, objects ...runtime.Object) *PodMetadataMutator {
+	testScheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(testScheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := operatorv1alpha1.AddToScheme(testScheme); err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := admission.NewDecoder(testScheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mutator := &PodMetadataMutator{
+		ClusterCache: newFakeClusterCache(testScheme, objects...),
+		Log:          logf.Log.WithName("test"),
+		Recorder:     noopEventRecorder{},
+	}
+	if err := mutator.InjectDecoder(decoder); err != nil {
+		t.Fatal(err)
+	}
+	return mutator
+}
+
+// getPatchedAnnotations admits web pod with given annotations and returns annotation keys changed by the mutator
+func getPatchedAnnotations(t *testing.T, mutator *PodMetadataMutator, namespace string, annotations map[string]string) map[string]bool {
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: namespace, Labels: map[string]string{"app": "web"},
+			Annotations: annotations},
+	}
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := mutator.Handle(context.TODO(), admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+		Namespace: namespace,
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}})
+	if !response.Allowed {
+		t.Fatalf("expected pod to be allowed, got %v", response.Result)
+	}
+	patched := map[string]bool{}
+	for _, patch := range response.Patches {
+		patched[patch.Path] = true
+		if patch.Path == "/metadata/annotations" {
+			if value, ok := patch.Value.(map[string]interface{}); ok {
+				for key := range value {
+					patched["/metadata/annotations/"+key] = true
+				}
+			}
+		}
+	}
+	return patched
+}
+
+func TestPodMetadataMutatorKeepsHigherPrecedenceValue(t *testing.T) {
+	const namespace = "app"
+	mutator := newTestPodMetadataMutator(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+		&operatorv1alpha1.IBMLicensingMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "keep", Namespace: namespace},
+			Spec: operatorv1alpha1.IBMLicensingMetadataSpec{
+				Condition:      webPodCondition,
+				Extend:         map[string]string{"productID": "keep-id"},
+				Priority:       10,
+				ConflictPolicy: operatorv1alpha1.ConflictPolicyKeep,
+			},
+		},
+		&operatorv1alpha1.IBMLicensingMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "overwrite", Namespace: namespace},
+			Spec: operatorv1alpha1.IBMLicensingMetadataSpec{
+				Condition:      webPodCondition,
+				Extend:         map[string]string{"productID": "overwrite-id", "productName": "name"},
+				ConflictPolicy: operatorv1alpha1.ConflictPolicyOverwrite,
+			},
+		})
+
+	patched := getPatchedAnnotations(t, mutator, namespace, map[string]string{"productID": "pod-id"})
+	if patched["/metadata/annotations/productID"] {
+		t.Error("expected productID kept by higher precedence IBMLicensingMetadata")
+	}
+	if !patched["/metadata/annotations/productName"] {
+		t.Errorf("expected productName to be applied, got patches %v", patched)
+	}
+}
+
+func TestPodMetadataMutatorAppliesMetadataFromApplicationNamespaces(t *testing.T) {
+	const namespace = "app"
+	mutator := newTestPodMetadataMutator(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ibm-common-services"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"team": "apps"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "catalog"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		// metadata from the namespace of the pod
+		&operatorv1alpha1.IBMLicensingMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "own", Namespace: namespace},
+			Spec: operatorv1alpha1.IBMLicensingMetadataSpec{
+				Condition: webPodCondition,
+				Extend:    map[string]string{"productName": "name"},
+			},
+		},
+		// metadata from another application namespace, which selects namespace of the pod
+		&operatorv1alpha1.IBMLicensingMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "selected", Namespace: "catalog"},
+			Spec: operatorv1alpha1.IBMLicensingMetadataSpec{
+				Condition: operatorv1alpha1.IBMLicensingMetadataCondition{
+					PodSelector:       webPodCondition.PodSelector,
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "apps"}},
+				},
+				Extend: map[string]string{"productID": "id"},
+			},
+		},
+		// metadata from another namespace without namespaceSelector matches only pods of its namespace
+		&operatorv1alpha1.IBMLicensingMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "other"},
+			Spec: operatorv1alpha1.IBMLicensingMetadataSpec{
+				Condition: webPodCondition,
+				Extend:    map[string]string{"productMetric": "FREE"},
+			},
+		})
+
+	patched := getPatchedAnnotations(t, mutator, namespace, nil)
+	if !patched["/metadata/annotations/productName"] || !patched["/metadata/annotations/productID"] {
+		t.Errorf("expected annotations from application namespaces to be applied, got patches %v", patched)
+	}
+	if patched["/metadata/annotations/productMetric"] {
+		t.Error("expected IBMLicensingMetadata from other namespace not to be applied")
+	}
+}
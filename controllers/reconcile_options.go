@@ -0,0 +1,63 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package controllers
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// DefaultBackoffBase is the delay before the first retry of a failed reconciliation
+	DefaultBackoffBase = time.Second
+	// DefaultBackoffMax is the maximum delay between retries of a failed reconciliation
+	DefaultBackoffMax = 5 * time.Minute
+)
+
+// ReconcileOptions configure concurrency and retries of operand controllers
+type ReconcileOptions struct {
+	// MaxConcurrentReconciles is the maximum number of reconciliations run in parallel by a controller, defaults to 1
+	MaxConcurrentReconciles int
+	// BackoffBase and BackoffMax bound exponential backoff of requests, which failed with an error
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// controllerOptions returns controller options, in which failed requests are retried with per item exponential backoff
+func (o ReconcileOptions) controllerOptions() controller.Options {
+	maxConcurrentReconciles := o.MaxConcurrentReconciles
+	if maxConcurrentReconciles < 1 {
+		maxConcurrentReconciles = 1
+	}
+	backoffBase := o.BackoffBase
+	if backoffBase <= 0 {
+		backoffBase = DefaultBackoffBase
+	}
+	backoffMax := o.BackoffMax
+	if backoffMax <= 0 {
+		backoffMax = DefaultBackoffMax
+	}
+	if backoffMax < backoffBase {
+		backoffMax = backoffBase
+	}
+	return controller.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(backoffBase, backoffMax),
+	}
+}
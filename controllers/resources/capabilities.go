@@ -0,0 +1,91 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultRHMPEnabled is the default of spec.rhmpEnabled, Red Hat Marketplace metering is not discovered
+const DefaultRHMPEnabled = false
+
+// Capabilities describe optional APIs available in the cluster, which change resources created by the operator
+type Capabilities struct {
+	// RouteAPI is true when OpenShift Route API is available
+	RouteAPI bool `json:"routeAPI"`
+	// ServiceCAAPI is true when OpenShift service CA can issue certificates for services
+	ServiceCAAPI bool `json:"serviceCAAPI"`
+	// ODLM is true when Operand Deployment Lifecycle Manager API is available
+	ODLM bool `json:"odlm"`
+	// ServiceMonitorAPI is true when Prometheus operator ServiceMonitor API is available
+	ServiceMonitorAPI bool `json:"serviceMonitorAPI"`
+	// MeterDefinitionAPI is true when Red Hat Marketplace MeterDefinition API is available
+	MeterDefinitionAPI bool `json:"meterDefinitionAPI"`
+	// CertManagerAPI is true when cert-manager Certificate API is available
+	CertManagerAPI bool `json:"certManagerAPI"`
+}
+
+// CapabilityResource is an API resource, which availability is one of Capabilities
+type CapabilityResource struct {
+	Name         string
+	GroupVersion schema.GroupVersion
+	Resource     string
+	field        func(*Capabilities) *bool
+}
+
+// CapabilityResources lists API resources, which are discovered to fill Capabilities
+var CapabilityResources = []CapabilityResource{
+	{"route", schema.GroupVersion{Group: "route.openshift.io", Version: "v1"}, "routes",
+		func(c *Capabilities) *bool { return &c.RouteAPI }},
+	{"serviceCA", schema.GroupVersion{Group: "operator.openshift.io", Version: "v1"}, "servicecas",
+		func(c *Capabilities) *bool { return &c.ServiceCAAPI }},
+	{"odlm", schema.GroupVersion{Group: "operator.ibm.com", Version: "v1alpha1"}, "operandbindinfos",
+		func(c *Capabilities) *bool { return &c.ODLM }},
+	{"serviceMonitor", schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}, "servicemonitors",
+		func(c *Capabilities) *bool { return &c.ServiceMonitorAPI }},
+	{"meterDefinition", schema.GroupVersion{Group: "marketplace.redhat.com", Version: "v1beta1"}, "meterdefinitions",
+		func(c *Capabilities) *bool { return &c.MeterDefinitionAPI }},
+	{"certManager", CertificateGroupVersion, "certificates",
+		func(c *Capabilities) *bool { return &c.CertManagerAPI }},
+}
+
+// CRDName returns name of CRD, which installs the resource
+func (r CapabilityResource) CRDName() string {
+	return r.Resource + "." + r.GroupVersion.Group
+}
+
+// IsAvailable returns true when the resource is available according to capabilities
+func (r CapabilityResource) IsAvailable(capabilities Capabilities) bool {
+	return *r.field(&capabilities)
+}
+
+// SetAvailable marks the resource as available or not in capabilities
+func (r CapabilityResource) SetAvailable(capabilities *Capabilities, available bool) {
+	*r.field(capabilities) = available
+}
+
+// IsServiceCACertsSource returns true when certificates are issued by OpenShift service CA
+func (c Capabilities) IsServiceCACertsSource(source v1alpha1.HTTPSCertsSource) bool {
+	return c.ServiceCAAPI && source == v1alpha1.OcpCertsSource
+}
+
+// IsIssuedCertsSource returns true when certificates are issued to secrets mounted in operands, by OpenShift service CA
+// or by cert-manager or the operator CA
+func (c Capabilities) IsIssuedCertsSource(source v1alpha1.HTTPSCertsSource) bool {
+	return c.IsServiceCACertsSource(source) || source == v1alpha1.CertManagerCertsSource || source == v1alpha1.OperatorCACertsSource
+}
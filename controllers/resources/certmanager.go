@@ -0,0 +1,86 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"errors"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CertificateGroupVersion is the API version of cert-manager Certificates created by the operator
+var CertificateGroupVersion = schema.GroupVersion{Group: "cert-manager.io", Version: "v1"}
+
+// CertificateKind is the kind of cert-manager Certificate
+const CertificateKind = "Certificate"
+
+const defaultIssuerKind = "Issuer"
+
+// NewCertificate returns empty cert-manager Certificate, which is unstructured as cert-manager API is optional
+func NewCertificate() *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(CertificateGroupVersion.WithKind(CertificateKind))
+	return certificate
+}
+
+// CheckCertManager returns error when certificates can not be issued by cert-manager
+func CheckCertManager(capabilities Capabilities, issuer *v1alpha1.CertManagerIssuer) error {
+	if issuer == nil || issuer.Name == "" {
+		return errors.New("certManagerIssuer must be set when certificates source is cert-manager")
+	}
+	if !capabilities.CertManagerAPI {
+		return errors.New("certificates source is cert-manager, but cert-manager is not available in the cluster")
+	}
+	return nil
+}
+
+// GetCertificate returns cert-manager Certificate for the service, which is issued to secret mounted in operand pods
+func GetCertificate(serviceName string, namespace string, secretName string, issuer v1alpha1.CertManagerIssuer,
+	labels map[string]string) *unstructured.Unstructured {
+	issuerKind := issuer.Kind
+	if issuerKind == "" {
+		issuerKind = defaultIssuerKind
+	}
+	issuerGroup := issuer.Group
+	if issuerGroup == "" {
+		issuerGroup = CertificateGroupVersion.Group
+	}
+
+	dnsNames := []interface{}{}
+	for _, dnsName := range GetServiceDNSNames(serviceName, namespace) {
+		dnsNames = append(dnsNames, dnsName)
+	}
+
+	certificate := NewCertificate()
+	certificate.SetName(secretName)
+	certificate.SetNamespace(namespace)
+	certificate.SetLabels(labels)
+	certificate.Object["spec"] = map[string]interface{}{
+		"secretName": secretName,
+		"commonName": serviceName + "." + namespace + ".svc",
+		"dnsNames":   dnsNames,
+		"usages":     []interface{}{"server auth", "digital signature", "key encipherment"},
+		"issuerRef": map[string]interface{}{
+			"name":  issuer.Name,
+			"kind":  issuerKind,
+			"group": issuerGroup,
+		},
+	}
+	return certificate
+}
@@ -0,0 +1,129 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetCertificate(t *testing.T) {
+	tests := []struct {
+		name          string
+		issuer        v1alpha1.CertManagerIssuer
+		expectedKind  string
+		expectedGroup string
+	}{
+		{
+			name:          "issuer with default kind and group",
+			issuer:        v1alpha1.CertManagerIssuer{Name: "issuer"},
+			expectedKind:  "Issuer",
+			expectedGroup: "cert-manager.io",
+		},
+		{
+			name:          "cluster issuer",
+			issuer:        v1alpha1.CertManagerIssuer{Name: "issuer", Kind: "ClusterIssuer"},
+			expectedKind:  "ClusterIssuer",
+			expectedGroup: "cert-manager.io",
+		},
+		{
+			name:          "external issuer",
+			issuer:        v1alpha1.CertManagerIssuer{Name: "issuer", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+			expectedKind:  "AWSPCAIssuer",
+			expectedGroup: "awspca.cert-manager.io",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			labels := map[string]string{"app": "ibm-licensing-service-instance"}
+			certificate := GetCertificate("ibm-licensing-service-instance", "ibm-common-services",
+				"ibm-license-service-cert", test.issuer, labels)
+
+			if certificate.GroupVersionKind() != CertificateGroupVersion.WithKind(CertificateKind) {
+				t.Errorf("unexpected kind %v", certificate.GroupVersionKind())
+			}
+			if certificate.GetName() != "ibm-license-service-cert" || certificate.GetNamespace() != "ibm-common-services" {
+				t.Errorf("unexpected certificate %s/%s", certificate.GetNamespace(), certificate.GetName())
+			}
+			if !reflect.DeepEqual(certificate.GetLabels(), labels) {
+				t.Errorf("unexpected labels %v", certificate.GetLabels())
+			}
+			secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+			if secretName != "ibm-license-service-cert" {
+				t.Errorf("expected certificate issued to ibm-license-service-cert secret, got %s", secretName)
+			}
+			commonName, _, _ := unstructured.NestedString(certificate.Object, "spec", "commonName")
+			if commonName != "ibm-licensing-service-instance.ibm-common-services.svc" {
+				t.Errorf("unexpected common name %s", commonName)
+			}
+			dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+			expectedDNSNames := []string{
+				"ibm-licensing-service-instance",
+				"ibm-licensing-service-instance.ibm-common-services",
+				"ibm-licensing-service-instance.ibm-common-services.svc",
+				"ibm-licensing-service-instance.ibm-common-services.svc.cluster.local",
+			}
+			if !reflect.DeepEqual(dnsNames, expectedDNSNames) {
+				t.Errorf("expected DNS names %v, got %v", expectedDNSNames, dnsNames)
+			}
+			issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+			expectedIssuerRef := map[string]string{"name": "issuer", "kind": test.expectedKind, "group": test.expectedGroup}
+			if !reflect.DeepEqual(issuerRef, expectedIssuerRef) {
+				t.Errorf("expected issuer %v, got %v", expectedIssuerRef, issuerRef)
+			}
+		})
+	}
+}
+
+func TestCheckCertManager(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities Capabilities
+		issuer       *v1alpha1.CertManagerIssuer
+		valid        bool
+	}{
+		{
+			name:         "cert-manager available",
+			capabilities: Capabilities{CertManagerAPI: true},
+			issuer:       &v1alpha1.CertManagerIssuer{Name: "issuer"},
+			valid:        true,
+		},
+		{
+			name:   "cert-manager not available",
+			issuer: &v1alpha1.CertManagerIssuer{Name: "issuer"},
+		},
+		{
+			name:         "issuer not set",
+			capabilities: Capabilities{CertManagerAPI: true},
+		},
+		{
+			name:         "issuer without name",
+			capabilities: Capabilities{CertManagerAPI: true},
+			issuer:       &v1alpha1.CertManagerIssuer{Kind: "ClusterIssuer"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := CheckCertManager(test.capabilities, test.issuer); (err == nil) != test.valid {
+				t.Errorf("expected valid %v, got error %v", test.valid, err)
+			}
+		})
+	}
+}
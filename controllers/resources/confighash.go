@@ -0,0 +1,304 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	c "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Prefixes of pod template annotations with content hashes of referenced secrets and configmaps, change of the hash
+// rolls out pods of the deployment
+const (
+	SecretHashAnnotationPrefix    = "secret.hash.operator.ibm.com/"
+	ConfigMapHashAnnotationPrefix = "configmap.hash.operator.ibm.com/"
+)
+
+// maxAnnotationNameLength is the limit of annotation key name part, longer object names are shortened with their hash
+const maxAnnotationNameLength = 63
+
+// ConfigReferences are names of secrets and configmaps mounted or referenced in environment variables of a pod, with
+// keys consumed by the pod for objects which are not consumed whole
+type ConfigReferences struct {
+	Secrets       []string
+	ConfigMaps    []string
+	SecretKeys    map[string][]string
+	ConfigMapKeys map[string][]string
+}
+
+// consumedKeys are keys consumed from secrets or configmaps by their names, nil keys mean the whole object
+type consumedKeys map[string]map[string]bool
+
+// add records keys consumed from the object, no keys mean the whole object
+func (consumed consumedKeys) add(name string, keys ...string) {
+	objectKeys, found := consumed[name]
+	if found && objectKeys == nil {
+		return
+	}
+	if len(keys) == 0 {
+		consumed[name] = nil
+		return
+	}
+	if objectKeys == nil {
+		objectKeys = map[string]bool{}
+		consumed[name] = objectKeys
+	}
+	for _, key := range keys {
+		objectKeys[key] = true
+	}
+}
+
+// names returns sorted names of consumed objects
+func (consumed consumedKeys) names() []string {
+	names := map[string]bool{}
+	for name := range consumed {
+		names[name] = true
+	}
+	return sortedNames(names)
+}
+
+// keys returns sorted keys of objects which are not consumed whole
+func (consumed consumedKeys) keys() map[string][]string {
+	keys := map[string][]string{}
+	for name, objectKeys := range consumed {
+		if name != "" && objectKeys != nil {
+			keys[name] = sortedNames(objectKeys)
+		}
+	}
+	return keys
+}
+
+// GetConfigReferences returns names of secrets and configmaps used by volumes and containers of the pod, keys mounted
+// with subPath, listed in volume items or referenced in environment variables are the only keys consumed from the object
+// unless it is also mounted or referenced whole
+func GetConfigReferences(podSpec corev1.PodSpec) ConfigReferences {
+	secrets := consumedKeys{}
+	configMaps := consumedKeys{}
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			secrets.add(volume.Secret.SecretName, getVolumeKeys(volume.Name, volume.Secret.Items, containers)...)
+		}
+		if volume.ConfigMap != nil {
+			configMaps.add(volume.ConfigMap.Name, getVolumeKeys(volume.Name, volume.ConfigMap.Items, containers)...)
+		}
+		if volume.Projected != nil {
+			for _, projection := range volume.Projected.Sources {
+				if projection.Secret != nil {
+					secrets.add(projection.Secret.Name, getItemKeys(projection.Secret.Items)...)
+				}
+				if projection.ConfigMap != nil {
+					configMaps.add(projection.ConfigMap.Name, getItemKeys(projection.ConfigMap.Items)...)
+				}
+			}
+		}
+	}
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secrets.add(env.ValueFrom.SecretKeyRef.Name, env.ValueFrom.SecretKeyRef.Key)
+			}
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps.add(env.ValueFrom.ConfigMapKeyRef.Name, env.ValueFrom.ConfigMapKeyRef.Key)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets.add(envFrom.SecretRef.Name)
+			}
+			if envFrom.ConfigMapRef != nil {
+				configMaps.add(envFrom.ConfigMapRef.Name)
+			}
+		}
+	}
+	return ConfigReferences{
+		Secrets:       secrets.names(),
+		ConfigMaps:    configMaps.names(),
+		SecretKeys:    secrets.keys(),
+		ConfigMapKeys: configMaps.keys(),
+	}
+}
+
+// getVolumeKeys returns keys consumed through mounts of the volume, nil when the whole object is consumed
+func getVolumeKeys(volumeName string, items []corev1.KeyToPath, containers []corev1.Container) []string {
+	var subPaths []string
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name != volumeName {
+				continue
+			}
+			if mount.SubPath == "" {
+				return getItemKeys(items)
+			}
+			subPaths = append(subPaths, strings.SplitN(mount.SubPath, "/", 2)[0])
+		}
+	}
+	if len(subPaths) == 0 || len(items) == 0 {
+		// without items, files of the volume are named by keys
+		return subPaths
+	}
+	var keys []string
+	for _, subPath := range subPaths {
+		for _, item := range items {
+			if strings.SplitN(item.Path, "/", 2)[0] == subPath {
+				keys = append(keys, item.Key)
+			}
+		}
+	}
+	return keys
+}
+
+func getItemKeys(items []corev1.KeyToPath) []string {
+	var keys []string
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+// Contains returns true when the secret or configmap is referenced
+func (references ConfigReferences) Contains(object runtime.Object, name string) bool {
+	names := references.ConfigMaps
+	if _, isSecret := object.(*corev1.Secret); isSecret {
+		names = references.Secrets
+	}
+	for _, referenced := range names {
+		if referenced == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConfigHashes returns pod template annotations with content hashes of the referenced secrets and configmaps,
+// only consumed keys are hashed, so that changes of other keys do not roll out pods, objects which do not exist yet are
+// skipped, so that pods are rolled out when they are created
+func GetConfigHashes(reader c.Reader, namespace string, references ConfigReferences) (map[string]string, error) {
+	hashes := map[string]string{}
+	for _, name := range references.Secrets {
+		secret := &corev1.Secret{}
+		if err := reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		hashes[SecretHashAnnotationPrefix+getAnnotationName(name)] = hashData(filterData(secret.Data, references.SecretKeys[name]), nil)
+	}
+	for _, name := range references.ConfigMaps {
+		configMap := &corev1.ConfigMap{}
+		if err := reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		keys := references.ConfigMapKeys[name]
+		hashes[ConfigMapHashAnnotationPrefix+getAnnotationName(name)] = hashData(filterData(configMap.BinaryData, keys),
+			filterStringData(configMap.Data, keys))
+	}
+	return hashes, nil
+}
+
+// AnnotationsForPodWithConfigHashes returns pod annotations extended with content hashes of secrets and configmaps
+func AnnotationsForPodWithConfigHashes(configHashes map[string]string) map[string]string {
+	annotations := AnnotationsForPod()
+	for key, hash := range configHashes {
+		annotations[key] = hash
+	}
+	return annotations
+}
+
+func hashData(data map[string][]byte, stringData map[string]string) string {
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	for key := range stringData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		value, ok := data[key]
+		if !ok {
+			value = []byte(stringData[key])
+		}
+		// lengths separate keys from values, so that moving bytes between them changes the hash
+		hash.Write([]byte(strconv.Itoa(len(key)) + ":" + key + strconv.Itoa(len(value)) + ":"))
+		hash.Write(value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// filterData returns data with the keys only, all data when keys are nil
+func filterData(data map[string][]byte, keys []string) map[string][]byte {
+	if keys == nil {
+		return data
+	}
+	filtered := map[string][]byte{}
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// filterStringData returns string data with the keys only, all data when keys are nil
+func filterStringData(data map[string]string, keys []string) map[string]string {
+	if keys == nil {
+		return data
+	}
+	filtered := map[string]string{}
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+func getAnnotationName(name string) string {
+	if len(name) <= maxAnnotationNameLength {
+		return name
+	}
+	nameHash := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(nameHash[:])[:8]
+	return name[:maxAnnotationNameLength-len(suffix)-1] + "-" + suffix
+}
+
+func sortedNames(names map[string]bool) []string {
+	var sorted []string
+	for name := range names {
+		if name != "" {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
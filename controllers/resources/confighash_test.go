@@ -0,0 +1,226 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const configHashNamespace = "ibm-common-services"
+
+func TestGetConfigReferences(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "secret", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "volume-secret"}}},
+			{Name: "configmap", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "volume-configmap"}}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-secret"}}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-configmap"}}},
+			}}}},
+		},
+		InitContainers: []corev1.Container{{
+			Name: "init",
+			Env: []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "env-secret"}, Key: "token"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Name: "main",
+			Env: []corev1.EnvVar{{Name: "URL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "env-configmap"}, Key: "url"}}}},
+			EnvFrom: []corev1.EnvFromSource{
+				{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "volume-secret"}}},
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "envfrom-configmap"}}},
+			},
+		}},
+	}
+	references := GetConfigReferences(podSpec)
+	expected := ConfigReferences{
+		Secrets:       []string{"env-secret", "projected-secret", "volume-secret"},
+		ConfigMaps:    []string{"env-configmap", "envfrom-configmap", "projected-configmap", "volume-configmap"},
+		SecretKeys:    map[string][]string{"env-secret": {"token"}},
+		ConfigMapKeys: map[string][]string{"env-configmap": {"url"}},
+	}
+	if !reflect.DeepEqual(references, expected) {
+		t.Errorf("expected references %v, got %v", expected, references)
+	}
+	if !references.Contains(&corev1.Secret{}, "env-secret") || references.Contains(&corev1.ConfigMap{}, "env-secret") {
+		t.Error("expected env-secret to be referenced only as secret")
+	}
+}
+
+func TestGetConfigReferencesOfConsumedKeys(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "subpath", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "subpath-secret"}}},
+			{Name: "items", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "items-secret",
+				Items: []corev1.KeyToPath{{Key: "token-previous", Path: "token"}, {Key: "unused", Path: "unused"}}}}},
+			{Name: "whole", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "whole-secret"}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "projected-configmap"},
+					Items: []corev1.KeyToPath{{Key: "expiry", Path: "expiry"}}}},
+			}}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "main",
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "subpath", MountPath: "/token", SubPath: "token"},
+				{Name: "items", MountPath: "/previous-token", SubPath: "token"},
+				{Name: "whole", MountPath: "/whole/token", SubPath: "token"},
+				{Name: "whole", MountPath: "/whole/"},
+				{Name: "projected", MountPath: "/projected/"},
+			},
+			Env: []corev1.EnvVar{{Name: "WHOLE", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "whole-secret"}, Key: "token"}}}},
+		}},
+	}
+	references := GetConfigReferences(podSpec)
+	expected := ConfigReferences{
+		Secrets:       []string{"items-secret", "subpath-secret", "whole-secret"},
+		ConfigMaps:    []string{"projected-configmap"},
+		SecretKeys:    map[string][]string{"items-secret": {"token-previous"}, "subpath-secret": {"token"}},
+		ConfigMapKeys: map[string][]string{"projected-configmap": {"expiry"}},
+	}
+	if !reflect.DeepEqual(references, expected) {
+		t.Errorf("expected references %v, got %v", expected, references)
+	}
+}
+
+func TestGetConfigHashesOfConsumedKeys(t *testing.T) {
+	references := ConfigReferences{Secrets: []string{"token"}, SecretKeys: map[string][]string{"token": {"token"}}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: configHashNamespace},
+		Data:       map[string][]byte{"token": []byte("first"), "token-previous": []byte("previous")},
+	}
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme, secret)
+	getHash := func() string {
+		hashes, err := GetConfigHashes(reader, configHashNamespace, references)
+		if err != nil {
+			t.Fatalf("failed to get config hashes: %v", err)
+		}
+		return hashes[SecretHashAnnotationPrefix+"token"]
+	}
+
+	initial := getHash()
+	delete(secret.Data, "token-previous")
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	if unchanged := getHash(); unchanged != initial {
+		t.Error("expected hash to stay the same when key which is not consumed is removed")
+	}
+
+	secret.Data["token"] = []byte("second")
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	if changed := getHash(); changed == initial {
+		t.Error("expected hash to change when consumed key changes")
+	}
+}
+
+func TestGetConfigHashes(t *testing.T) {
+	references := ConfigReferences{Secrets: []string{"token", "missing"}, ConfigMaps: []string{"config"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: configHashNamespace},
+		Data:       map[string][]byte{"token": []byte("first")},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: configHashNamespace},
+		Data:       map[string]string{"url": "https://first"},
+	}
+	reader := fake.NewFakeClientWithScheme(scheme.Scheme, secret, configMap)
+	getAnnotations := func() map[string]string {
+		hashes, err := GetConfigHashes(reader, configHashNamespace, references)
+		if err != nil {
+			t.Fatalf("failed to get config hashes: %v", err)
+		}
+		return AnnotationsForPodWithConfigHashes(hashes)
+	}
+	secretAnnotation := SecretHashAnnotationPrefix + "token"
+	configMapAnnotation := ConfigMapHashAnnotationPrefix + "config"
+
+	initial := getAnnotations()
+	if initial[secretAnnotation] == "" || initial[configMapAnnotation] == "" {
+		t.Fatalf("expected hashes of secret and configmap in annotations, got %v", initial)
+	}
+	if _, ok := initial[SecretHashAnnotationPrefix+"missing"]; ok {
+		t.Errorf("expected missing secret to be skipped, got %v", initial)
+	}
+
+	// metadata changes do not change data, so pods are not rolled out
+	secret.Labels = map[string]string{"app": "licensing"}
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	if unchanged := getAnnotations(); !reflect.DeepEqual(initial, unchanged) {
+		t.Errorf("expected annotations to stay the same when data does not change, got %v instead of %v", unchanged, initial)
+	}
+
+	secret.Data["token"] = []byte("second")
+	if err := reader.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	secretChanged := getAnnotations()
+	if secretChanged[secretAnnotation] == initial[secretAnnotation] {
+		t.Error("expected secret hash to change when secret data changes")
+	}
+	if secretChanged[configMapAnnotation] != initial[configMapAnnotation] {
+		t.Error("expected configmap hash to stay the same when only secret data changes")
+	}
+
+	configMap.Data["url"] = "https://second"
+	if err := reader.Update(context.TODO(), configMap); err != nil {
+		t.Fatal(err)
+	}
+	configMapChanged := getAnnotations()
+	if configMapChanged[configMapAnnotation] == secretChanged[configMapAnnotation] {
+		t.Error("expected configmap hash to change when configmap data changes")
+	}
+	if configMapChanged[secretAnnotation] != secretChanged[secretAnnotation] {
+		t.Error("expected secret hash to stay the same when only configmap data changes")
+	}
+}
+
+func TestHashDataSeparatesKeysAndValues(t *testing.T) {
+	if hashData(map[string][]byte{"ab": []byte("c")}, nil) == hashData(map[string][]byte{"a": []byte("bc")}, nil) {
+		t.Error("expected different hashes when bytes move between key and value")
+	}
+	if hashData(nil, map[string]string{"key": "value"}) != hashData(map[string][]byte{"key": []byte("value")}, nil) {
+		t.Error("expected the same hash of configmap data and binary data with the same content")
+	}
+}
+
+func TestGetAnnotationNameShortensLongNames(t *testing.T) {
+	longName := strings.Repeat("a", 100)
+	otherLongName := strings.Repeat("a", 99) + "b"
+	name := getAnnotationName(longName)
+	if len(name) > maxAnnotationNameLength {
+		t.Errorf("expected annotation name of at most %d characters, got %d", maxAnnotationNameLength, len(name))
+	}
+	if name == getAnnotationName(otherLongName) {
+		t.Error("expected different annotation names of long names with the same prefix")
+	}
+}
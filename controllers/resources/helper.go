@@ -19,28 +19,20 @@ package resources
 import (
 	"context"
 	"crypto/rand"
-	"errors"
-	"fmt"
 	"math/big"
-	"os"
 	"reflect"
-	"time"
 
 	networkingv1 "k8s.io/api/networking/v1"
 
-	odlm "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
-
-	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/go-logr/logr"
 	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
-	servicecav1 "github.com/openshift/api/operator/v1"
-	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	c "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -52,11 +44,6 @@ var FalseVar = false
 var DefaultSecretMode int32 = 420
 var Seconds60 int64 = 60
 
-var IsRouteAPI = true
-var IsServiceCAAPI = true
-var RHMPEnabled = false
-var IsUIEnabled = false
-var IsODLM = true
 var UIPlatformSecretName = "platform-oidc-credentials"
 
 var PathType = networkingv1.PathTypeImplementationSpecific
@@ -66,29 +53,34 @@ const LicensingProductName = "IBM Cloud Platform Common Services"
 const LicensingProductID = "068a62892a1e4db39641342e592daa25"
 const LicensingProductMetric = "FREE"
 
+// FieldManager is the name of the operator in managed fields of resources applied with server-side apply
+const FieldManager = "ibm-licensing-operator"
+
 const randStringCharset string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 const ocpCertSecretNameTag = "service.beta.openshift.io/serving-cert-secret-name" // #nosec
 const OcpCheckString = "ocp-check-secret"
 const OcpPrometheusCheckString = "ocp-prometheus-check-secret"
 
-var randStringCharsetLength = big.NewInt(int64(len(randStringCharset)))
-
-var annotationsForServicesToCheck = [...]string{ocpCertSecretNameTag}
-
 type ResourceObject interface {
 	metav1.Object
 	runtime.Object
 }
 
 func RandString(length int) (string, error) {
+	return RandStringFromCharset(length, randStringCharset)
+}
+
+// RandStringFromCharset returns random string of given length with characters of the single-byte charset
+func RandStringFromCharset(length int, charset string) (string, error) {
 	reader := rand.Reader
+	charsetLength := big.NewInt(int64(len(charset)))
 	outputStringByte := make([]byte, length)
 	for i := 0; i < length; i++ {
-		charIndex, err := rand.Int(reader, randStringCharsetLength)
+		charIndex, err := rand.Int(reader, charsetLength)
 		if err != nil {
 			return "", err
 		}
-		outputStringByte[i] = randStringCharset[charIndex.Int64()]
+		outputStringByte[i] = charset[charIndex.Int64()]
 	}
 	return string(outputStringByte), nil
 }
@@ -124,50 +116,30 @@ func GetSecretToken(name string, namespace string, secretKey string, metaLabels
 	return expectedSecret, nil
 }
 
-func AnnotateForService(httpCertSource v1alpha1.HTTPSCertsSource, isHTTPS bool, certName string) map[string]string {
-	if IsServiceCAAPI && isHTTPS && httpCertSource == v1alpha1.OcpCertsSource {
+func AnnotateForService(capabilities Capabilities, httpCertSource v1alpha1.HTTPSCertsSource, isHTTPS bool, certName string) map[string]string {
+	if isHTTPS && capabilities.IsServiceCACertsSource(httpCertSource) {
 		return map[string]string{ocpCertSecretNameTag: certName}
 	}
 	return map[string]string{}
 }
 
-func UpdateResource(reqLogger *logr.Logger, client c.Client,
-	expectedResource ResourceObject, foundResource ResourceObject) (reconcile.Result, error) {
+// ApplyResource creates or updates resource with server-side apply. Fields set in expected resource are owned by
+// the operator and their drift is corrected, while fields managed by others, f.e. replicas set by autoscaler, are kept.
+func ApplyResource(reqLogger *logr.Logger, client c.Client, scheme *runtime.Scheme, expectedResource ResourceObject) (reconcile.Result, error) {
 	resTypeString := reflect.TypeOf(expectedResource).String()
-	(*reqLogger).Info("Updating " + resTypeString)
-	expectedResource.SetResourceVersion(foundResource.GetResourceVersion())
-	err := client.Update(context.TODO(), expectedResource)
+	gvk, err := apiutil.GVKForObject(expectedResource, scheme)
 	if err != nil {
-		// only need to delete resource as new will be recreated on next reconciliation
-		(*reqLogger).Info("Could not update "+resTypeString+", due to having not compatible changes between expected and updated resource, "+
-			"will try to delete it and create new one...", "Namespace", foundResource.GetNamespace(), "Name", foundResource.GetName())
-		return DeleteResource(reqLogger, client, foundResource)
-	}
-	(*reqLogger).Info("Updated "+resTypeString+" successfully", "Namespace", expectedResource.GetNamespace(), "Name", expectedResource.GetName())
-	// Resource updated - return and do not requeue as it might not consider extra values
-	return reconcile.Result{}, nil
-}
-
-func UpdateServiceIfNeeded(reqLogger *logr.Logger, client c.Client, expectedService *corev1.Service, foundService *corev1.Service) (reconcile.Result, error) {
-	for _, annotation := range annotationsForServicesToCheck {
-		if foundService.Annotations[annotation] != expectedService.Annotations[annotation] {
-			expectedService.Spec.ClusterIP = foundService.Spec.ClusterIP
-			return UpdateResource(reqLogger, client, expectedService, foundService)
-		}
-	}
-	return reconcile.Result{}, nil
-}
-
-func UpdateServiceMonitor(reqLogger *logr.Logger, client c.Client, expected, found *monitoringv1.ServiceMonitor) (reconcile.Result, error) {
-	if expected != nil && found != nil && expected.Spec.Endpoints[0].Scheme != found.Spec.Endpoints[0].Scheme {
-		return DeleteResource(reqLogger, client, found)
+		return reconcile.Result{}, err
 	}
-	for _, annotation := range annotationsForServicesToCheck {
-		//goland:noinspection GoNilness
-		if found.Annotations[annotation] != expected.Annotations[annotation] {
-			return UpdateResource(reqLogger, client, found, expected)
-		}
+	// apply patch requires type information, which typed objects do not have set
+	expectedResource.GetObjectKind().SetGroupVersionKind(gvk)
+	expectedResource.SetResourceVersion("")
+	err = client.Patch(context.TODO(), expectedResource, c.Apply, c.FieldOwner(FieldManager), c.ForceOwnership)
+	if err != nil {
+		(*reqLogger).Error(err, "Failed to apply "+resTypeString, "Namespace", expectedResource.GetNamespace(), "Name", expectedResource.GetName())
+		return reconcile.Result{}, err
 	}
+	(*reqLogger).Info("Applied "+resTypeString, "Namespace", expectedResource.GetNamespace(), "Name", expectedResource.GetName())
 	return reconcile.Result{}, nil
 }
 
@@ -182,10 +154,10 @@ func DeleteResource(reqLogger *logr.Logger, client c.Client, foundResource Resou
 			return reconcile.Result{}, err
 		}
 	} else {
-		// Resource deleted successfully - return and requeue to create new one
+		// Resource deleted successfully - deletion is observed by owned resources watch, no need to requeue
 		(*reqLogger).Info("Deleted "+resTypeString+" successfully", "Namespace", foundResource.GetNamespace(), "Name", foundResource.GetName())
 	}
-	return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 30}, nil
+	return reconcile.Result{}, nil
 }
 
 func UpdateOwner(reqLogger *logr.Logger, client c.Client, owner ResourceObject) (reconcile.Result, error) {
@@ -221,73 +193,3 @@ echo "$(date): All required secrets exist"
 `
 	return script
 }
-
-func UpdateCacheClusterExtensions(client c.Reader) error {
-	var watchNamespaceEnvVar = "WATCH_NAMESPACE"
-
-	namespace, found := os.LookupEnv(watchNamespaceEnvVar)
-	if !found {
-		return errors.New("WATCH_NAMESPACE not found")
-	}
-
-	listOpts := []c.ListOption{
-		c.InNamespace(namespace),
-	}
-
-	routeTestInstance := &routev1.Route{}
-	if err := client.List(context.TODO(), routeTestInstance, listOpts...); err == nil {
-		IsRouteAPI = true
-	} else {
-		IsRouteAPI = false
-	}
-
-	serviceCAInstance := &servicecav1.ServiceCA{}
-	if err := client.List(context.TODO(), serviceCAInstance, listOpts...); err == nil {
-		IsServiceCAAPI = true
-	} else {
-		IsServiceCAAPI = false
-	}
-
-	odlmTestInstance := &odlm.OperandBindInfo{}
-	if err := client.List(context.TODO(), odlmTestInstance, listOpts...); err == nil {
-		IsODLM = true
-	} else {
-		IsODLM = false
-	}
-
-	return nil
-}
-
-// Returns true if configmaps are equal
-func CompareConfigMap(cm1, cm2 *corev1.ConfigMap) bool {
-	return reflect.DeepEqual(cm1.Data, cm2.Data) && reflect.DeepEqual(cm1.Labels, cm2.Labels)
-}
-
-// Returns true if routes are equal
-func CompareRoutes(reqLogger logr.Logger, expectedRoute, foundRoute *routev1.Route) bool {
-	areEqual := false
-	if foundRoute.ObjectMeta.Name != expectedRoute.ObjectMeta.Name {
-		reqLogger.Info("Names not equal", "old", foundRoute.ObjectMeta.Name, "new", expectedRoute.ObjectMeta.Name)
-	} else if foundRoute.Spec.To.Name != expectedRoute.Spec.To.Name {
-		reqLogger.Info("Specs To Name not equal",
-			"old", fmt.Sprintf("%v", foundRoute.Spec),
-			"new", fmt.Sprintf("%v", expectedRoute.Spec))
-	} else if foundRoute.Spec.TLS == nil && expectedRoute.Spec.TLS != nil {
-		reqLogger.Info("Found Route has empty TLS options, but Expected Route has not empty TLS options",
-			"old", fmt.Sprintf("%v", foundRoute.Spec.TLS),
-			"new", fmt.Sprintf("%v", expectedRoute.Spec.TLS))
-	} else if foundRoute.Spec.TLS != nil && expectedRoute.Spec.TLS == nil {
-		reqLogger.Info("Expected Route has empty TLS options, but Found Route has not empty TLS options",
-			"old", fmt.Sprintf("%v", foundRoute.Spec.TLS),
-			"new", fmt.Sprintf("%v", expectedRoute.Spec.TLS))
-	} else if foundRoute.Spec.TLS != nil && expectedRoute.Spec.TLS != nil &&
-		(foundRoute.Spec.TLS.Termination != expectedRoute.Spec.TLS.Termination ||
-			foundRoute.Spec.TLS.InsecureEdgeTerminationPolicy != expectedRoute.Spec.TLS.InsecureEdgeTerminationPolicy) {
-		reqLogger.Info("Expected Route has different TLS options than Found Route",
-			"old", fmt.Sprintf("%v", foundRoute.Spec.TLS),
-			"new", fmt.Sprintf("%v", expectedRoute.Spec.TLS))
-	} else {
-		areEqual = true
-	}
-	return areEqual
-}
@@ -0,0 +1,84 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaxSecretDataSize is the limit of data which can be stored in a secret
+const MaxSecretDataSize = 1024 * 1024
+
+const operandAPITimeout = 2 * time.Minute
+
+// GetSecretValue returns value stored under key of the secret
+func GetSecretValue(client client.Client, name string, namespace string, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s does not contain %s key", name, key)
+	}
+	return string(value), nil
+}
+
+// CallOperandAPI sends GET request with query parameters to API of an operand reached through its cluster service,
+// and returns response body, failing when it exceeds maxSize bytes
+func CallOperandAPI(endpoint string, query url.Values, maxSize int) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.URL.RawQuery = query.Encode()
+
+	httpClient := &http.Client{
+		Timeout: operandAPITimeout,
+		Transport: &http.Transport{
+			// operands serve cluster services with certificates, which may be self-signed
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec
+		},
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed with status %s", endpoint, response.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", endpoint, err)
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", endpoint, maxSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,323 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	c "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OperatorCASecretName is the secret with CA maintained by the operator, in namespace of operands using it
+const OperatorCASecretName = "ibm-licensing-operator-ca" // #nosec
+
+// CABundleConfigMapName is the ConfigMap publishing certificates of the operator CA, which clients of operands should trust
+const CABundleConfigMapName = "ibm-licensing-ca-bundle"
+
+// CABundleKey is the key of CA certificates in CA bundle ConfigMap and in secrets with certificates issued by the operator CA
+const CABundleKey = "ca.crt"
+
+// caPreviousCertKey keeps certificate of the previous CA in CA secret, it is trusted until it expires
+const caPreviousCertKey = "previous.crt"
+
+const (
+	caValidity             = 5 * 365 * 24 * time.Hour
+	caRenewBefore          = 365 * 24 * time.Hour
+	certificateValidity    = 90 * 24 * time.Hour
+	certificateRenewBefore = 30 * 24 * time.Hour
+	rsaKeyBits             = 2048
+	// certificates are valid since an hour before they are issued, to tolerate clock skew
+	notBeforeBackdate = time.Hour
+)
+
+// caPropagationDelay is the time after renewal of the CA, during which certificates issued by the previous CA are kept,
+// so that clients receive the CA bundle with the new CA before the services present certificates issued by it
+const caPropagationDelay = 24 * time.Hour
+
+// OperatorCA is the CA maintained by the operator, which issues certificates of operands
+type OperatorCA struct {
+	certificate *x509.Certificate
+	key         *rsa.PrivateKey
+	// previousCertificate is certificate of the previous CA, nil when it expired
+	previousCertificate *x509.Certificate
+	// Bundle is PEM encoded certificate of the CA followed by certificate of the previous CA, if it did not expire yet
+	Bundle []byte
+	// Status of the CA certificate
+	Status v1alpha1.CertificateStatus
+}
+
+// GetServiceDNSNames returns DNS names of the service used in its certificates
+func GetServiceDNSNames(serviceName string, namespace string) []string {
+	return []string{
+		serviceName,
+		serviceName + "." + namespace,
+		serviceName + "." + namespace + ".svc",
+		serviceName + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+// GetOperatorCA returns CA from the CA secret in the namespace, the CA is created when the secret does not exist and
+// renewed at its renewal time, certificate of the previous CA stays in the bundle until it expires
+func GetOperatorCA(reader c.Reader, writer c.Writer, namespace string, now time.Time) (*OperatorCA, error) {
+	secret := &corev1.Secret{}
+	err := reader.Get(context.TODO(), types.NamespacedName{Name: OperatorCASecretName, Namespace: namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	var previousCert []byte
+	if exists {
+		ca, err := parseOperatorCA(secret, now)
+		if err == nil && now.Before(ca.Status.RenewalTime.Time) {
+			return ca, nil
+		}
+		if err == nil {
+			previousCert = secret.Data[corev1.TLSCertKey]
+		}
+	}
+
+	certPEM, keyPEM, err := generateCA(now)
+	if err != nil {
+		return nil, err
+	}
+	secret.Name = OperatorCASecretName
+	secret.Namespace = namespace
+	secret.Labels = map[string]string{"app.kubernetes.io/managed-by": "operator"}
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}
+	if previousCert != nil {
+		secret.Data[caPreviousCertKey] = previousCert
+	}
+	// conflicting changes of the CA by reconciliation of other instance fail and are retried with the stored CA
+	if exists {
+		err = writer.Update(context.TODO(), secret)
+	} else {
+		err = writer.Create(context.TODO(), secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseOperatorCA(secret, now)
+}
+
+func parseOperatorCA(secret *corev1.Secret, now time.Time) (*OperatorCA, error) {
+	certificate, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+	keyBlock, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+	if keyBlock == nil {
+		return nil, errors.New("CA key is not PEM encoded")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ca := &OperatorCA{
+		certificate: certificate,
+		key:         key,
+		Bundle:      append([]byte{}, secret.Data[corev1.TLSCertKey]...),
+		Status:      getCertificateStatus(secret.GetName(), certificate, caRenewBefore),
+	}
+	if previous, err := parseCertificate(secret.Data[caPreviousCertKey]); err == nil && now.Before(previous.NotAfter) {
+		ca.previousCertificate = previous
+		ca.Bundle = append(ca.Bundle, secret.Data[caPreviousCertKey]...)
+	}
+	return ca, nil
+}
+
+// GetCertificateSecret returns TLS secret with certificate of the service issued by the CA, certificate from the existing
+// secret is kept until its renewal time, unless it was issued by other CA or for other DNS names, certificate issued by
+// the previous CA is kept until the new CA propagates to clients
+func (ca *OperatorCA) GetCertificateSecret(reader c.Reader, name string, namespace string, dnsNames []string,
+	labels map[string]string, now time.Time) (*corev1.Secret, v1alpha1.CertificateStatus, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Type:       corev1.SecretTypeTLS,
+	}
+	found := &corev1.Secret{}
+	err := reader.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, found)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, v1alpha1.CertificateStatus{}, err
+	}
+	if err == nil {
+		if certificate, err := parseCertificate(found.Data[corev1.TLSCertKey]); err == nil && len(found.Data[corev1.TLSPrivateKeyKey]) > 0 {
+			if renewalTime, ok := ca.getRenewalTime(certificate, dnsNames); ok && now.Before(renewalTime) {
+				secret.Data = map[string][]byte{
+					corev1.TLSCertKey:       found.Data[corev1.TLSCertKey],
+					corev1.TLSPrivateKeyKey: found.Data[corev1.TLSPrivateKeyKey],
+					CABundleKey:             ca.Bundle,
+				}
+				return secret, v1alpha1.CertificateStatus{
+					Name:        name,
+					NotAfter:    metav1.Time{Time: certificate.NotAfter},
+					RenewalTime: metav1.Time{Time: renewalTime},
+				}, nil
+			}
+		}
+	}
+
+	certPEM, keyPEM, certificate, err := ca.issue(dnsNames, now)
+	if err != nil {
+		return nil, v1alpha1.CertificateStatus{}, err
+	}
+	secret.Data = map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, CABundleKey: ca.Bundle}
+	return secret, getCertificateStatus(name, certificate, certificateRenewBefore), nil
+}
+
+// getRenewalTime returns time when the certificate must be issued again, ok is false when it was not issued for the DNS
+// names by the CA or by the previous CA, certificate issued by the previous CA is renewed when the new CA propagated
+func (ca *OperatorCA) getRenewalTime(certificate *x509.Certificate, dnsNames []string) (renewalTime time.Time, ok bool) {
+	expected := append([]string{}, dnsNames...)
+	actual := append([]string{}, certificate.DNSNames...)
+	sort.Strings(expected)
+	sort.Strings(actual)
+	if !reflect.DeepEqual(expected, actual) {
+		return time.Time{}, false
+	}
+	renewalTime = certificate.NotAfter.Add(-certificateRenewBefore)
+	if certificate.CheckSignatureFrom(ca.certificate) == nil {
+		return renewalTime, true
+	}
+	if ca.previousCertificate == nil || certificate.CheckSignatureFrom(ca.previousCertificate) != nil {
+		return time.Time{}, false
+	}
+	if propagationTime := ca.certificate.NotBefore.Add(notBeforeBackdate + caPropagationDelay); propagationTime.Before(renewalTime) {
+		renewalTime = propagationTime
+	}
+	return renewalTime, true
+}
+
+func (ca *OperatorCA) issue(dnsNames []string, now time.Time) ([]byte, []byte, *x509.Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serialNumber, err := getSerialNumber()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-notBeforeBackdate),
+		NotAfter:     now.Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return encodeCertificate(der), encodeKey(key), certificate, nil
+}
+
+func generateCA(now time.Time) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := getSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "ibm-licensing-operator-ca@" + now.UTC().Format("20060102150405")},
+		NotBefore:             now.Add(-notBeforeBackdate),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertificate(der), encodeKey(key), nil
+}
+
+func getSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func encodeCertificate(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("certificate is not PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func getCertificateStatus(name string, certificate *x509.Certificate, renewBefore time.Duration) v1alpha1.CertificateStatus {
+	return v1alpha1.CertificateStatus{
+		Name:        name,
+		NotAfter:    metav1.Time{Time: certificate.NotAfter},
+		RenewalTime: metav1.Time{Time: certificate.NotAfter.Add(-renewBefore)},
+	}
+}
+
+// GetCABundleConfigMap returns ConfigMap publishing the CA bundle, it has no owner as it is shared by instances
+func GetCABundleConfigMap(namespace string, bundle []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CABundleConfigMapName,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "operator"},
+		},
+		Data: map[string]string{CABundleKey: string(bytes.TrimSpace(bundle)) + "\n"},
+	}
+}
+
+// GetRenewalDelay returns time until the earliest renewal of certificates, zero when there are no certificates
+func GetRenewalDelay(certificates []v1alpha1.CertificateStatus, now time.Time) time.Duration {
+	var times []time.Time
+	for _, certificate := range certificates {
+		times = append(times, certificate.RenewalTime.Time)
+	}
+	return getDelay(times, now)
+}
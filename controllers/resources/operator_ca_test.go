@@ -0,0 +1,248 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const operatorCANamespace = "ibm-common-services"
+
+const serviceCertSecretName = "ibm-license-service-cert"
+
+var serviceDNSNames = GetServiceDNSNames("ibm-licensing-service-instance", operatorCANamespace)
+
+// newCASecret returns CA secret with CA issued at given time, certificate of previous CA secret is kept when it is set
+func newCASecret(t *testing.T, issuedAt time.Time, previous *corev1.Secret) *corev1.Secret {
+	certPEM, keyPEM, err := generateCA(issuedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorCASecretName, Namespace: operatorCANamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+	if previous != nil {
+		secret.Data[caPreviousCertKey] = previous.Data[corev1.TLSCertKey]
+	}
+	return secret
+}
+
+// newServiceCertSecret returns secret with certificate for the DNS names issued by CA of the CA secret at given time
+func newServiceCertSecret(t *testing.T, caSecret *corev1.Secret, dnsNames []string, issuedAt time.Time) *corev1.Secret {
+	ca, err := parseOperatorCA(caSecret, issuedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, keyPEM, _, err := ca.issue(dnsNames, issuedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceCertSecretName, Namespace: operatorCANamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM},
+	}
+}
+
+func TestOperatorCA(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	caRenewalTime := caValidity - caRenewBefore
+
+	validCA := newCASecret(t, now.Add(-30*24*time.Hour), nil)
+	expiringCA := newCASecret(t, now.Add(-caRenewalTime-time.Hour), nil)
+	expiredCA := newCASecret(t, now.Add(-caValidity-time.Hour), nil)
+	renewedCA := newCASecret(t, now.Add(-2*time.Hour), expiringCA)
+	propagatedCA := newCASecret(t, now.Add(-caPropagationDelay-2*time.Hour), expiringCA)
+	renewedAfterExpiryCA := newCASecret(t, now.Add(-2*time.Hour), expiredCA)
+	corruptedCA := validCA.DeepCopy()
+	corruptedCA.Data[corev1.TLSCertKey] = []byte("corrupted")
+
+	tests := []struct {
+		name     string
+		caSecret *corev1.Secret
+		// certificate secret of the service, nil when it does not exist
+		certSecret *corev1.Secret
+		dnsNames   []string
+		// CA certificate expected in the CA secret, the CA is expected to be created when it is nil
+		expectedCACert []byte
+		// number of certificates expected in the CA bundle
+		expectedBundleSize int
+		certKept           bool
+		// renewal time of kept certificate, renewal time of its certificate when it is zero
+		expectedRenewalTime time.Time
+	}{
+		{
+			name:               "CA and certificate created",
+			dnsNames:           serviceDNSNames,
+			expectedBundleSize: 1,
+		},
+		{
+			name:               "valid certificate kept",
+			caSecret:           validCA,
+			certSecret:         newServiceCertSecret(t, validCA, serviceDNSNames, now.Add(-24*time.Hour)),
+			dnsNames:           serviceDNSNames,
+			expectedCACert:     validCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 1,
+			certKept:           true,
+		},
+		{
+			name:               "certificate renewed before it expires",
+			caSecret:           validCA,
+			certSecret:         newServiceCertSecret(t, validCA, serviceDNSNames, now.Add(-certificateValidity+certificateRenewBefore-time.Hour)),
+			dnsNames:           serviceDNSNames,
+			expectedCACert:     validCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 1,
+		},
+		{
+			name:       "CA renewed, certificate of previous CA kept until the new CA propagates",
+			caSecret:   expiringCA,
+			certSecret: newServiceCertSecret(t, expiringCA, serviceDNSNames, now.Add(-24*time.Hour)),
+			dnsNames:   serviceDNSNames,
+			// new CA is created at now
+			expectedBundleSize:  2,
+			certKept:            true,
+			expectedRenewalTime: now.Add(caPropagationDelay),
+		},
+		{
+			name:                "certificate of previous CA kept during propagation",
+			caSecret:            renewedCA,
+			certSecret:          newServiceCertSecret(t, expiringCA, serviceDNSNames, now.Add(-24*time.Hour)),
+			dnsNames:            serviceDNSNames,
+			expectedCACert:      renewedCA.Data[corev1.TLSCertKey],
+			expectedBundleSize:  2,
+			certKept:            true,
+			expectedRenewalTime: now.Add(caPropagationDelay - 2*time.Hour),
+		},
+		{
+			name:               "certificate of previous CA renewed after propagation",
+			caSecret:           propagatedCA,
+			certSecret:         newServiceCertSecret(t, expiringCA, serviceDNSNames, now.Add(-caPropagationDelay-3*time.Hour)),
+			dnsNames:           serviceDNSNames,
+			expectedCACert:     propagatedCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 2,
+		},
+		{
+			name:               "expired previous CA removed from bundle",
+			caSecret:           renewedAfterExpiryCA,
+			certSecret:         newServiceCertSecret(t, expiredCA, serviceDNSNames, now.Add(-caValidity)),
+			dnsNames:           serviceDNSNames,
+			expectedCACert:     renewedAfterExpiryCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 1,
+		},
+		{
+			name:               "certificate renewed when DNS names change",
+			caSecret:           validCA,
+			certSecret:         newServiceCertSecret(t, validCA, serviceDNSNames, now.Add(-24*time.Hour)),
+			dnsNames:           append(append([]string{}, serviceDNSNames...), "localhost"),
+			expectedCACert:     validCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 1,
+		},
+		{
+			name:       "corrupted CA secret replaced",
+			caSecret:   corruptedCA,
+			certSecret: newServiceCertSecret(t, validCA, serviceDNSNames, now.Add(-24*time.Hour)),
+			dnsNames:   serviceDNSNames,
+			// certificate of corrupted CA can not be trusted, so it is not kept in the bundle
+			expectedBundleSize: 1,
+		},
+		{
+			name:     "corrupted certificate secret replaced",
+			caSecret: validCA,
+			certSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceCertSecretName, Namespace: operatorCANamespace},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("corrupted"), corev1.TLSPrivateKeyKey: []byte("corrupted")},
+			},
+			dnsNames:           serviceDNSNames,
+			expectedCACert:     validCA.Data[corev1.TLSCertKey],
+			expectedBundleSize: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if test.caSecret != nil {
+				objects = append(objects, test.caSecret.DeepCopy())
+			}
+			if test.certSecret != nil {
+				objects = append(objects, test.certSecret.DeepCopy())
+			}
+			client := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+
+			ca, err := GetOperatorCA(client, client, operatorCANamespace, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			storedCA := &corev1.Secret{}
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: OperatorCASecretName, Namespace: operatorCANamespace},
+				storedCA); err != nil {
+				t.Fatal(err)
+			}
+			if test.expectedCACert != nil && !bytes.Equal(storedCA.Data[corev1.TLSCertKey], test.expectedCACert) {
+				t.Error("expected CA to be kept")
+			}
+			if test.expectedCACert == nil && ca.certificate.NotBefore != now.Add(-notBeforeBackdate) {
+				t.Errorf("expected CA to be created, got CA issued at %v", ca.certificate.NotBefore)
+			}
+			if bundleSize := bytes.Count(ca.Bundle, []byte("BEGIN CERTIFICATE")); bundleSize != test.expectedBundleSize {
+				t.Errorf("expected %d certificates in CA bundle, got %d", test.expectedBundleSize, bundleSize)
+			}
+
+			secret, status, err := ca.GetCertificateSecret(client, serviceCertSecretName, operatorCANamespace, test.dnsNames, nil, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(secret.Data[CABundleKey], ca.Bundle) {
+				t.Error("expected CA bundle in certificate secret")
+			}
+			certificate, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+			if err != nil {
+				t.Fatal(err)
+			}
+			kept := test.certSecret != nil && bytes.Equal(secret.Data[corev1.TLSCertKey], test.certSecret.Data[corev1.TLSCertKey])
+			if kept != test.certKept {
+				t.Errorf("expected certificate kept %v, got %v", test.certKept, kept)
+			}
+			if !kept {
+				if err := certificate.CheckSignatureFrom(ca.certificate); err != nil {
+					t.Errorf("expected certificate issued by the CA: %v", err)
+				}
+				if err := certificate.VerifyHostname(test.dnsNames[len(test.dnsNames)-1]); err != nil {
+					t.Error(err)
+				}
+			}
+			expectedRenewalTime := certificate.NotAfter.Add(-certificateRenewBefore)
+			if !test.expectedRenewalTime.IsZero() {
+				expectedRenewalTime = test.expectedRenewalTime
+			}
+			if !status.RenewalTime.Time.Equal(expectedRenewalTime) {
+				t.Errorf("expected renewal time %v, got %v", expectedRenewalTime, status.RenewalTime.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package reporter
+
+import (
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	c "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetCertificate returns cert-manager Certificate of License Service Reporter service, issued to the same secret as
+// certificate of OpenShift service CA
+func GetCertificate(instance *operatorv1alpha1.IBMLicenseServiceReporter) *unstructured.Unstructured {
+	return resources.GetCertificate(LicenseReporterResourceBase, instance.GetNamespace(), LicenseReportOCPCertName,
+		*instance.Spec.CertManagerIssuer, LabelsForMeta(instance))
+}
+
+// GetCertificateSecret returns secret with certificate of License Service Reporter service issued by the operator CA,
+// to the same secret as certificate of OpenShift service CA, the certificate is valid for localhost used by the UI
+func GetCertificateSecret(reader c.Reader, instance *operatorv1alpha1.IBMLicenseServiceReporter, ca *resources.OperatorCA,
+	now time.Time) (*corev1.Secret, operatorv1alpha1.CertificateStatus, error) {
+	dnsNames := append(resources.GetServiceDNSNames(LicenseReporterResourceBase, instance.GetNamespace()), "localhost")
+	return ca.GetCertificateSecret(reader, LicenseReportOCPCertName, instance.GetNamespace(), dnsNames, LabelsForMeta(instance), now)
+}
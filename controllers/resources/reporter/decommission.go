@@ -0,0 +1,77 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package reporter
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CleanupFinalizer is added to IBMLicenseServiceReporter to deconfigure senders and export data before it is deleted
+const CleanupFinalizer = "operator.ibm.com/reporter-cleanup"
+
+// SenderConfiguredByAnnotation is set on IBMLicensing instances to namespace/name of IBMLicenseServiceReporter
+// which configured their default sender parameters
+const SenderConfiguredByAnnotation = "operator.ibm.com/sender-configured-by"
+
+const FinalExportSecretName = "ibm-license-service-reporter-final-export"
+const FinalExportKey = "export.zip"
+const FinalExportTimeKey = "exportTime"
+
+const exportPath = "/snapshot"
+
+// GetSenderConfiguredByValue returns value of SenderConfiguredByAnnotation identifying the instance
+func GetSenderConfiguredByValue(instance *operatorv1alpha1.IBMLicenseServiceReporter) string {
+	return instance.GetNamespace() + "/" + instance.GetName()
+}
+
+func GetReceiverURL(instance *operatorv1alpha1.IBMLicenseServiceReporter) string {
+	return "https://" + LicenseReporterResourceBase + "." + instance.GetNamespace() + ".svc.cluster.local:" + strconv.Itoa(ReceiverPort)
+}
+
+// ExportAggregatedData downloads data aggregated by License Service Reporter receiver, authenticated with the API token
+// of the instance
+func ExportAggregatedData(client client.Client, instance *operatorv1alpha1.IBMLicenseServiceReporter) ([]byte, error) {
+	token, err := resources.GetSecretValue(client, instance.Spec.APISecretToken, instance.GetNamespace(), APIReciverSecretTokenKeyName)
+	if err != nil {
+		return nil, err
+	}
+	return resources.CallOperandAPI(GetReceiverURL(instance)+exportPath, url.Values{"token": {token}}, resources.MaxSecretDataSize)
+}
+
+// GetFinalExportSecret returns secret storing exported data, it has no owner so that it is kept after the instance is deleted
+func GetFinalExportSecret(instance *operatorv1alpha1.IBMLicenseServiceReporter, data []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FinalExportSecretName,
+			Namespace: instance.GetNamespace(),
+			Labels:    LabelsForMeta(instance),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			FinalExportKey:     data,
+			FinalExportTimeKey: []byte(time.Now().UTC().Format(time.RFC3339)),
+		},
+	}
+}
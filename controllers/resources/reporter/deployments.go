@@ -24,9 +24,31 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-var replicas = int32(1)
+// GetConfigReferences returns secrets and configmaps mounted or referenced in environment variables of License Service
+// Reporter pods
+func GetConfigReferences(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities res.Capabilities, uiEnabled bool) res.ConfigReferences {
+	return res.GetConfigReferences(corev1.PodSpec{
+		Volumes:        getLicenseServiceReporterVolumes(instance.Spec, capabilities),
+		InitContainers: GetLicenseReporterInitContainers(instance, capabilities),
+		Containers:     getContainers(instance, capabilities, uiEnabled),
+	})
+}
 
-func GetDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter) *appsv1.Deployment {
+func getContainers(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities res.Capabilities, uiEnabled bool) []corev1.Container {
+	containers := []corev1.Container{
+		GetDatabaseContainer(instance),
+		GetReceiverContainer(instance, capabilities),
+	}
+	if uiEnabled {
+		containers = append(containers, GetReporterUIContainer(instance))
+	}
+	return containers
+}
+
+// GetDeployment returns License Service Reporter deployment, configHashes are stamped into pod template annotations,
+// so that pods are rolled out when referenced secrets or configmaps change
+func GetDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities res.Capabilities, uiEnabled bool,
+	configHashes map[string]string) *appsv1.Deployment {
 	metaLabels := LabelsForMeta(instance)
 	selectorLabels := LabelsForSelector(instance)
 	podLabels := LabelsForPod(instance)
@@ -38,13 +60,6 @@ func GetDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter) *appsv1
 		}
 	}
 
-	containers := []corev1.Container{
-		GetDatabaseContainer(instance),
-		GetReceiverContainer(instance),
-	}
-	if res.IsUIEnabled {
-		containers = append(containers, GetReporterUIContainer(instance))
-	}
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      GetResourceName(instance),
@@ -52,7 +67,7 @@ func GetDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter) *appsv1
 			Labels:    metaLabels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			// replicas are not applied, so that scaling by others, f.e. by autoscaler, is kept, new deployment has 1 replica
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
@@ -62,12 +77,12 @@ func GetDeployment(instance *operatorv1alpha1.IBMLicenseServiceReporter) *appsv1
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podLabels,
-					Annotations: res.AnnotationsForPod(),
+					Annotations: res.AnnotationsForPodWithConfigHashes(configHashes),
 				},
 				Spec: corev1.PodSpec{
-					Volumes:                       getLicenseServiceReporterVolumes(instance.Spec),
-					InitContainers:                GetLicenseReporterInitContainers(instance),
-					Containers:                    containers,
+					Volumes:                       getLicenseServiceReporterVolumes(instance.Spec, capabilities),
+					InitContainers:                GetLicenseReporterInitContainers(instance, capabilities),
+					Containers:                    getContainers(instance, capabilities, uiEnabled),
 					TerminationGracePeriodSeconds: &res.Seconds60,
 					ServiceAccountName:            GetServiceAccountName(instance),
 					ImagePullSecrets:              imagePullSecrets,
@@ -19,21 +19,24 @@ package reporter
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	"github.com/go-logr/logr"
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
-	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/version"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	odlm "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
 )
 
 const DatabaseConfigSecretName = "license-service-hub-db-config"
+
+// PendingDatabasePasswordSecretName is the secret with new database password during its rotation
+const PendingDatabasePasswordSecretName = "license-service-hub-db-config-rotation" // #nosec
+
+// DatabasePasswordRotatedAtAnnotation of database secret is the time of the last password rotation in RFC 3339 format
+const DatabasePasswordRotatedAtAnnotation = "operator.ibm.com/password-rotated-at"
 const PostgresPasswordKey = "POSTGRES_PASSWORD" // #nosec
 const PostgresUserKey = "POSTGRES_USER"
 const PostgresDatabaseNameKey = "POSTGRES_DB"
@@ -101,7 +104,7 @@ func getReciverEnvVariables(spec operatorv1alpha1.IBMLicenseServiceReporterSpec)
 	environmentVariables := []corev1.EnvVar{
 		{
 			Name:  "HTTPS_CERTS_SOURCE",
-			Value: string(spec.HTTPSCertsSource),
+			Value: string(spec.GetOperandCertsSource()),
 		},
 	}
 	if spec.EnvVariable != nil {
@@ -137,7 +140,9 @@ func UpdateVersion(client client.Client, instance *operatorv1alpha1.IBMLicenseSe
 	return nil
 }
 
-func AddSenderConfiguration(client client.Client, log logr.Logger) error {
+// AddSenderConfiguration sets default sender parameters in IBMLicensing instances, so that they send data to the instance,
+// and marks instances configured this way with SenderConfiguredByAnnotation
+func AddSenderConfiguration(client client.Client, instance *operatorv1alpha1.IBMLicenseServiceReporter, log logr.Logger) error {
 	licensingList := &operatorv1alpha1.IBMLicensingList{}
 	reqLogger := log.WithName("reconcileSenderConfiguration")
 
@@ -153,7 +158,19 @@ func AddSenderConfiguration(client client.Client, log logr.Logger) error {
 
 	for _, lic := range licensingList.Items {
 		licensing := lic
-		if licensing.Spec.SetDefaultSenderParameters() {
+		changed := licensing.Spec.SetDefaultSenderParameters()
+		// instances configured before the annotation was introduced are marked as well
+		if licensing.Spec.Sender.ReporterSecretToken == licensing.Spec.GetDefaultReporterTokenName() &&
+			licensing.GetAnnotations()[SenderConfiguredByAnnotation] == "" {
+			annotations := licensing.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[SenderConfiguredByAnnotation] = GetSenderConfiguredByValue(instance)
+			licensing.SetAnnotations(annotations)
+			changed = true
+		}
+		if changed {
 			err := client.Update(context.TODO(), &licensing)
 			if err != nil {
 				reqLogger.Error(err, fmt.Sprintf("Failed to configure sender for: %s", licensing.Name))
@@ -165,32 +182,34 @@ func AddSenderConfiguration(client client.Client, log logr.Logger) error {
 	return nil
 }
 
-func ClearDefaultSenderConfiguration(client client.Client, log logr.Logger) {
+// ClearDefaultSenderConfiguration removes default sender parameters from IBMLicensing instances configured by the instance
+func ClearDefaultSenderConfiguration(client client.Client, instance *operatorv1alpha1.IBMLicenseServiceReporter, log logr.Logger) error {
 	licensingList := &operatorv1alpha1.IBMLicensingList{}
-	reqLogger := log.WithName("reconcileSenderConfiguration")
+	reqLogger := log.WithName("clearSenderConfiguration")
 
 	err := client.List(context.TODO(), licensingList)
 	if err != nil {
 		reqLogger.Error(err, "Failed to get IBMLicensing resource")
-		return
-	}
-	if len(licensingList.Items) == 0 {
-		reqLogger.Info("License Service not installed")
-		return
+		return err
 	}
 
 	for _, lic := range licensingList.Items {
 		licensing := lic
-		if licensing.Spec.RemoveDefaultSenderParameters() {
-			err := client.Update(context.TODO(), &licensing)
-			if err != nil {
-				reqLogger.Error(err, fmt.Sprintf("Failed to removed sender for: %s", licensing.Name))
-				return
-			}
-			reqLogger.Info(fmt.Sprintf("Successfully removed sender for %s", licensing.Name))
-
+		if licensing.GetAnnotations()[SenderConfiguredByAnnotation] != GetSenderConfiguredByValue(instance) {
+			continue
 		}
+		licensing.Spec.RemoveDefaultSenderParameters()
+		annotations := licensing.GetAnnotations()
+		delete(annotations, SenderConfiguredByAnnotation)
+		licensing.SetAnnotations(annotations)
+		err := client.Update(context.TODO(), &licensing)
+		if err != nil {
+			reqLogger.Error(err, fmt.Sprintf("Failed to removed sender for: %s", licensing.Name))
+			return err
+		}
+		reqLogger.Info(fmt.Sprintf("Successfully removed sender for %s", licensing.Name))
 	}
+	return nil
 }
 
 func GetBindInfo(instance *operatorv1alpha1.IBMLicenseServiceReporter) *odlm.OperandBindInfo {
@@ -212,12 +231,3 @@ func GetBindInfo(instance *operatorv1alpha1.IBMLicenseServiceReporter) *odlm.Ope
 		},
 	}
 }
-
-func UpdateOperandBindInfoIfNeeded(reqLogger *logr.Logger, client client.Client, expectedBindInfo *odlm.OperandBindInfo,
-	foundBindInfo *odlm.OperandBindInfo) (reconcile.Result, error) {
-
-	if !reflect.DeepEqual(expectedBindInfo.Spec, foundBindInfo.Spec) {
-		return res.UpdateResource(reqLogger, client, expectedBindInfo, foundBindInfo)
-	}
-	return reconcile.Result{}, nil
-}
@@ -23,10 +23,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-func GetLicenseReporterInitContainers(instance *operatorv1alpha1.IBMLicenseServiceReporter) []corev1.Container {
+func GetLicenseReporterInitContainers(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities resources.Capabilities) []corev1.Container {
 	containers := []corev1.Container{}
-	if resources.IsServiceCAAPI && instance.Spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource {
-		baseContainer := GetReceiverContainer(instance)
+	if capabilities.IsIssuedCertsSource(instance.Spec.HTTPSCertsSource) {
+		baseContainer := GetReceiverContainer(instance, capabilities)
 		baseContainer.LivenessProbe = nil
 		baseContainer.ReadinessProbe = nil
 		ocpSecretCheckContainer := corev1.Container{}
@@ -55,11 +55,11 @@ func getReceiverProbeHandler() corev1.Handler {
 	}
 }
 
-func GetReceiverContainer(instance *operatorv1alpha1.IBMLicenseServiceReporter) corev1.Container {
+func GetReceiverContainer(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities resources.Capabilities) corev1.Container {
 	container := resources.GetContainerBase(instance.Spec.ReceiverContainer)
 	container.Env = getReciverEnvVariables(instance.Spec)
 	container.EnvFrom = getDatabaseEnvFromSourceVariables()
-	container.VolumeMounts = getVolumeMounts(instance.Spec)
+	container.VolumeMounts = getVolumeMounts(instance.Spec, capabilities)
 	container.Name = ReceiverContainerName
 	container.Ports = []corev1.ContainerPort{
 		{
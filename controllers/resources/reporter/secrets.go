@@ -17,6 +17,8 @@
 package reporter
 
 import (
+	"strings"
+
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	"github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/version"
@@ -59,7 +61,7 @@ func GetAPISecretToken(instance *operatorv1alpha1.IBMLicenseServiceReporter) (*c
 
 func GetDatabaseSecret(instance *operatorv1alpha1.IBMLicenseServiceReporter) (*corev1.Secret, error) {
 	metaLabels := LabelsForMeta(instance)
-	randString, err := resources.RandString(8)
+	randString, err := GetDatabasePassword(instance)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +82,40 @@ func GetDatabaseSecret(instance *operatorv1alpha1.IBMLicenseServiceReporter) (*c
 	return expectedSecret, nil
 }
 
+// GetDatabasePassword returns new database password generated according to the password policy of the instance,
+// the charset is validated also here, as the validating webhook is optional
+func GetDatabasePassword(instance *operatorv1alpha1.IBMLicenseServiceReporter) (string, error) {
+	charset := instance.Spec.GetDatabasePasswordCharset()
+	if err := operatorv1alpha1.ValidatePasswordCharset(charset); err != nil {
+		return "", err
+	}
+	return resources.RandStringFromCharset(instance.Spec.GetDatabasePasswordLength(), charset)
+}
+
+// GetPendingDatabasePasswordSecret returns secret, which keeps the new password while it is changed in the database,
+// so that the rotation is completed with the same password after a failure
+func GetPendingDatabasePasswordSecret(instance *operatorv1alpha1.IBMLicenseServiceReporter, password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PendingDatabasePasswordSecretName,
+			Namespace: instance.GetNamespace(),
+			Labels:    LabelsForMeta(instance),
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{PostgresPasswordKey: password},
+	}
+}
+
+// GetChangePasswordStatement returns SQL statement changing password of the database user
+func GetChangePasswordStatement(password string) string {
+	return "ALTER USER \"" + DatabaseUser + "\" WITH PASSWORD '" + strings.ReplaceAll(password, "'", "''") + "';\n"
+}
+
+// GetPsqlCommand returns command of database container running SQL statements from standard input as the database user
+func GetPsqlCommand() []string {
+	return []string{"psql", "-w", "-U", DatabaseUser, "-d", DatabaseName, "-v", "ON_ERROR_STOP=1", "-q"}
+}
+
 func GetZenConfigMap(instance *operatorv1alpha1.IBMLicenseServiceReporter) *corev1.ConfigMap {
 	labels := map[string]string{
 		"icpdata_addon":         "true",
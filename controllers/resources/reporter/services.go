@@ -54,14 +54,14 @@ func getServiceSpec(instance *operatorv1alpha1.IBMLicenseServiceReporter) corev1
 	}
 }
 
-func GetService(instance *operatorv1alpha1.IBMLicenseServiceReporter) *corev1.Service {
+func GetService(instance *operatorv1alpha1.IBMLicenseServiceReporter, capabilities resources.Capabilities) *corev1.Service {
 	metaLabels := LabelsForMeta(instance)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        LicenseReporterResourceBase,
 			Namespace:   instance.GetNamespace(),
 			Labels:      metaLabels,
-			Annotations: resources.AnnotateForService(instance.Spec.HTTPSCertsSource, true, LicenseReportOCPCertName),
+			Annotations: resources.AnnotateForService(capabilities, instance.Spec.HTTPSCertsSource, true, LicenseReportOCPCertName),
 		},
 		Spec: getServiceSpec(instance),
 	}
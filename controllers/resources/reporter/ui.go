@@ -39,10 +39,6 @@ func getReporterUIEnvironmentVariables(instance *operatorv1alpha1.IBMLicenseServ
 				},
 			},
 		},
-		{
-			Name:  "NODE_TLS_REJECT_UNAUTHORIZED",
-			Value: "0",
-		},
 		{
 			Name: "WLP_CLIENT_SECRET",
 			ValueFrom: &corev1.EnvVarSource{
@@ -82,6 +78,18 @@ func getReporterUIEnvironmentVariables(instance *operatorv1alpha1.IBMLicenseServ
 			Value: "https://icp-management-ingress/idprovider",
 		},
 	}
+	if instance.Spec.HTTPSCertsSource == operatorv1alpha1.OperatorCACertsSource {
+		// receiver certificate is issued for localhost too, so UI verifies it with the operator CA
+		environmentVariables = append(environmentVariables, corev1.EnvVar{
+			Name:  "NODE_EXTRA_CA_CERTS",
+			Value: reporterCertsMountPath + resources.CABundleKey,
+		})
+	} else {
+		environmentVariables = append(environmentVariables, corev1.EnvVar{
+			Name:  "NODE_TLS_REJECT_UNAUTHORIZED",
+			Value: "0",
+		})
+	}
 	if instance.Spec.EnvVariable != nil {
 		for key, value := range instance.Spec.EnvVariable {
 			environmentVariables = append(environmentVariables, corev1.EnvVar{
@@ -117,6 +125,9 @@ func GetReporterUIContainer(instance *operatorv1alpha1.IBMLicenseServiceReporter
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
+	if instance.Spec.HTTPSCertsSource == operatorv1alpha1.OperatorCACertsSource {
+		container.VolumeMounts = []corev1.VolumeMount{getHTTPSCertsVolumeMount()}
+	}
 	container.LivenessProbe = resources.GetLivenessProbe(getReporterUIProbeHandler())
 	container.ReadinessProbe = resources.GetReadinessProbe(getReporterUIProbeHandler())
 	return container
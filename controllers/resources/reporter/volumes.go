@@ -27,7 +27,9 @@ const LicenseReporterHTTPSCertsVolumeName = "license-reporter-https-certs"
 
 const persistentVolumeClaimVolumeName = "data"
 
-func getVolumeMounts(spec operatorv1alpha1.IBMLicenseServiceReporterSpec) []corev1.VolumeMount {
+const reporterCertsMountPath = "/opt/licensing/certs/"
+
+func getVolumeMounts(spec operatorv1alpha1.IBMLicenseServiceReporterSpec, capabilities resources.Capabilities) []corev1.VolumeMount {
 	var volumeMounts = []corev1.VolumeMount{
 		{
 			Name:      APISecretTokenVolumeName,
@@ -35,18 +37,20 @@ func getVolumeMounts(spec operatorv1alpha1.IBMLicenseServiceReporterSpec) []core
 			ReadOnly:  true,
 		},
 	}
-	if resources.IsServiceCAAPI && spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource {
-		volumeMounts = append(volumeMounts, []corev1.VolumeMount{
-			{
-				Name:      LicenseReporterHTTPSCertsVolumeName,
-				MountPath: "/opt/licensing/certs/",
-				ReadOnly:  true,
-			},
-		}...)
+	if capabilities.IsIssuedCertsSource(spec.HTTPSCertsSource) {
+		volumeMounts = append(volumeMounts, getHTTPSCertsVolumeMount())
 	}
 	return volumeMounts
 }
 
+func getHTTPSCertsVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      LicenseReporterHTTPSCertsVolumeName,
+		MountPath: reporterCertsMountPath,
+		ReadOnly:  true,
+	}
+}
+
 func getDatabaseVolumeMounts() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
 		{
@@ -56,7 +60,7 @@ func getDatabaseVolumeMounts() []corev1.VolumeMount {
 	}
 }
 
-func getLicenseServiceReporterVolumes(spec operatorv1alpha1.IBMLicenseServiceReporterSpec) []corev1.Volume {
+func getLicenseServiceReporterVolumes(spec operatorv1alpha1.IBMLicenseServiceReporterSpec, capabilities resources.Capabilities) []corev1.Volume {
 	volumes := []corev1.Volume{
 
 		{
@@ -78,7 +82,7 @@ func getLicenseServiceReporterVolumes(spec operatorv1alpha1.IBMLicenseServiceRep
 		},
 	}
 
-	if resources.IsServiceCAAPI && spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource {
+	if capabilities.IsIssuedCertsSource(spec.HTTPSCertsSource) {
 		volumes = append(volumes, resources.GetVolume(LicenseReporterHTTPSCertsVolumeName, LicenseReportOCPCertName))
 	}
 	return volumes
@@ -0,0 +1,68 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	c "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetCertificates returns cert-manager Certificates of License Service and Prometheus services, issued to the same
+// secrets as certificates of OpenShift service CA
+func GetCertificates(instance *operatorv1alpha1.IBMLicensing) []*unstructured.Unstructured {
+	namespace := instance.Spec.InstanceNamespace
+	issuer := *instance.Spec.CertManagerIssuer
+	certificates := []*unstructured.Unstructured{
+		resources.GetCertificate(GetResourceName(instance), namespace, LicenseServiceOCPCertName, issuer, LabelsForMeta(instance)),
+	}
+	if instance.Spec.IsRHMPEnabled() {
+		certificates = append(certificates,
+			resources.GetCertificate(GetPrometheusServiceName(), namespace, PrometheusServiceOCPCertName, issuer, LabelsForMeta(instance)))
+	}
+	return certificates
+}
+
+// GetCertificateSecrets returns secrets with certificates of License Service and Prometheus services issued by the
+// operator CA, to the same secrets as certificates of OpenShift service CA
+func GetCertificateSecrets(reader c.Reader, instance *operatorv1alpha1.IBMLicensing, ca *resources.OperatorCA,
+	now time.Time) ([]*corev1.Secret, []operatorv1alpha1.CertificateStatus, error) {
+	namespace := instance.Spec.InstanceNamespace
+	serviceNames := map[string]string{LicenseServiceOCPCertName: GetResourceName(instance)}
+	secretNames := []string{LicenseServiceOCPCertName}
+	if instance.Spec.IsRHMPEnabled() {
+		serviceNames[PrometheusServiceOCPCertName] = GetPrometheusServiceName()
+		secretNames = append(secretNames, PrometheusServiceOCPCertName)
+	}
+
+	var secrets []*corev1.Secret
+	var statuses []operatorv1alpha1.CertificateStatus
+	for _, secretName := range secretNames {
+		dnsNames := resources.GetServiceDNSNames(serviceNames[secretName], namespace)
+		secret, status, err := ca.GetCertificateSecret(reader, secretName, namespace, dnsNames, LabelsForMeta(instance), now)
+		if err != nil {
+			return nil, nil, err
+		}
+		secrets = append(secrets, secret)
+		statuses = append(statuses, status)
+	}
+	return secrets, statuses, nil
+}
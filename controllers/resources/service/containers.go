@@ -53,10 +53,17 @@ func getLicensingEnvironmentVariables(spec operatorv1alpha1.IBMLicensingSpec) []
 			Value: "verbose",
 		})
 	}
+	if spec.TokenRotation != nil {
+		// License Service accepts also the previous tokens found in the directory until their expiry times
+		environmentVariables = append(environmentVariables, corev1.EnvVar{
+			Name:  "PREVIOUS_TOKENS_PATH",
+			Value: PreviousTokensPath,
+		})
+	}
 	if spec.HTTPSEnable {
 		environmentVariables = append(environmentVariables, corev1.EnvVar{
 			Name:  "HTTPS_CERTS_SOURCE",
-			Value: string(spec.HTTPSCertsSource),
+			Value: string(spec.GetOperandCertsSource()),
 		})
 	}
 	if spec.IsMetering() {
@@ -197,10 +204,10 @@ echo "$(date): All required secrets exist"
 	return script
 }
 
-func GetLicensingInitContainers(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Container {
+func GetLicensingInitContainers(spec operatorv1alpha1.IBMLicensingSpec, capabilities resources.Capabilities) []corev1.Container {
 	containers := []corev1.Container{}
 	if spec.IsMetering() {
-		baseContainer := getLicensingContainerBase(spec)
+		baseContainer := getLicensingContainerBase(spec, capabilities)
 		meteringSecretCheckContainer := corev1.Container{}
 		baseContainer.DeepCopyInto(&meteringSecretCheckContainer)
 		meteringSecretCheckContainer.Name = "metering-check-secret"
@@ -211,8 +218,8 @@ func GetLicensingInitContainers(spec operatorv1alpha1.IBMLicensingSpec) []corev1
 		}
 		containers = append(containers, meteringSecretCheckContainer)
 	}
-	if resources.IsServiceCAAPI && spec.HTTPSEnable && spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource {
-		baseContainer := getLicensingContainerBase(spec)
+	if spec.HTTPSEnable && capabilities.IsIssuedCertsSource(spec.HTTPSCertsSource) {
+		baseContainer := getLicensingContainerBase(spec, capabilities)
 		ocpSecretCheckContainer := corev1.Container{}
 
 		baseContainer.DeepCopyInto(&ocpSecretCheckContainer)
@@ -225,7 +232,7 @@ func GetLicensingInitContainers(spec operatorv1alpha1.IBMLicensingSpec) []corev1
 		containers = append(containers, ocpSecretCheckContainer)
 
 		if spec.IsRHMPEnabled() {
-			baseContainer := getLicensingContainerBase(spec)
+			baseContainer := getLicensingContainerBase(spec, capabilities)
 			ocpPrometheusSecretCheckContainer := corev1.Container{}
 
 			baseContainer.DeepCopyInto(&ocpPrometheusSecretCheckContainer)
@@ -241,12 +248,12 @@ func GetLicensingInitContainers(spec operatorv1alpha1.IBMLicensingSpec) []corev1
 	return containers
 }
 
-func getLicensingContainerBase(spec operatorv1alpha1.IBMLicensingSpec) corev1.Container {
+func getLicensingContainerBase(spec operatorv1alpha1.IBMLicensingSpec, capabilities resources.Capabilities) corev1.Container {
 	container := resources.GetContainerBase(spec.Container)
 	if spec.SecurityContext != nil {
 		container.SecurityContext.RunAsUser = &spec.SecurityContext.RunAsUser
 	}
-	container.VolumeMounts = getLicensingVolumeMounts(spec)
+	container.VolumeMounts = getLicensingVolumeMounts(spec, capabilities)
 	container.Env = getLicensingEnvironmentVariables(spec)
 	container.Ports = getLicensingContainerPorts(spec)
 	return container
@@ -290,10 +297,10 @@ func getUsageContainerPorts() []corev1.ContainerPort {
 	return ports
 }
 
-func GetLicensingContainer(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Container {
+func GetLicensingContainer(spec operatorv1alpha1.IBMLicensingSpec, capabilities resources.Capabilities) []corev1.Container {
 	var containers []corev1.Container
 
-	licensingContainer := getLicensingContainerBase(spec)
+	licensingContainer := getLicensingContainerBase(spec, capabilities)
 	probeHandler := getProbeHandler(spec)
 	licensingContainer.Name = "license-service"
 	licensingContainer.LivenessProbe = resources.GetLivenessProbe(probeHandler)
@@ -0,0 +1,74 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"net/url"
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SnapshotFinalizer is added to IBMLicensing with spec.decommission.snapshotOnDelete to store audit snapshot before it is deleted
+const SnapshotFinalizer = "operator.ibm.com/licensing-snapshot"
+
+const FinalSnapshotSecretName = "ibm-licensing-final-snapshot"
+const SnapshotKey = "snapshot.zip"
+const SnapshotTimeKey = "snapshotTime"
+
+// finalSnapshotReservedSize is the part of the secret data limit reserved for the time of the audit snapshot, its
+// manifest and signature, which are stored in the final snapshot secret together with the archive
+const finalSnapshotReservedSize = 16 * 1024
+
+// MaxFinalSnapshotSize is the limit of audit snapshot archive which fits in the final snapshot secret
+const MaxFinalSnapshotSize = resources.MaxSecretDataSize - finalSnapshotReservedSize
+
+// SnapshotPath is path of License Service API returning audit snapshot archive
+const SnapshotPath = "/snapshot"
+
+// GetAuditSnapshot retrieves audit snapshot archive from License Service API, authenticated with the API token of the instance
+func GetAuditSnapshot(client client.Client, instance *operatorv1alpha1.IBMLicensing, query url.Values, maxSize int) ([]byte, error) {
+	token, err := resources.GetSecretValue(client, instance.Spec.APISecretToken, instance.Spec.InstanceNamespace, APISecretTokenKeyName)
+	if err != nil {
+		return nil, err
+	}
+	snapshotQuery := url.Values{"token": {token}}
+	for key, values := range query {
+		snapshotQuery[key] = values
+	}
+	return resources.CallOperandAPI(GetServiceURL(instance)+SnapshotPath, snapshotQuery, maxSize)
+}
+
+// GetFinalSnapshotSecret returns secret storing audit snapshot, it has no owner so that it is kept after the instance is deleted
+func GetFinalSnapshotSecret(instance *operatorv1alpha1.IBMLicensing, data []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FinalSnapshotSecretName,
+			Namespace: instance.Spec.InstanceNamespace,
+			Labels:    LabelsForMeta(instance),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			SnapshotKey:     data,
+			SnapshotTimeKey: []byte(time.Now().UTC().Format(time.RFC3339)),
+		},
+	}
+}
@@ -24,9 +24,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-var replicas = int32(1)
+// GetConfigReferences returns secrets and configmaps mounted or referenced in environment variables of License Service
+// pods, previous tokens are skipped, because kubelet updates their volume without restart, so that their retirement
+// does not roll out pods
+func GetConfigReferences(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities) resources.ConfigReferences {
+	var volumes []corev1.Volume
+	for _, volume := range getLicensingVolumes(instance.Spec, capabilities) {
+		if volume.Name != PreviousTokensVolumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+	return resources.GetConfigReferences(corev1.PodSpec{
+		Volumes:        volumes,
+		InitContainers: GetLicensingInitContainers(instance.Spec, capabilities),
+		Containers:     GetLicensingContainer(instance.Spec, capabilities),
+	})
+}
 
-func GetLicensingDeployment(instance *operatorv1alpha1.IBMLicensing) *appsv1.Deployment {
+// GetLicensingDeployment returns License Service deployment, configHashes are stamped into pod template annotations,
+// so that pods are rolled out when referenced secrets or configmaps change
+func GetLicensingDeployment(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities,
+	configHashes map[string]string) *appsv1.Deployment {
 	metaLabels := LabelsForMeta(instance)
 	selectorLabels := LabelsForSelector(instance)
 	podLabels := LabelsForLicensingPod(instance)
@@ -45,19 +63,19 @@ func GetLicensingDeployment(instance *operatorv1alpha1.IBMLicensing) *appsv1.Dep
 			Labels:    metaLabels,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
+			// replicas are not applied, so that scaling by others, f.e. by autoscaler, is kept, new deployment has 1 replica
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      podLabels,
-					Annotations: resources.AnnotationsForPod(),
+					Annotations: resources.AnnotationsForPodWithConfigHashes(configHashes),
 				},
 				Spec: corev1.PodSpec{
-					Volumes:                       getLicensingVolumes(instance.Spec),
-					InitContainers:                GetLicensingInitContainers(instance.Spec),
-					Containers:                    GetLicensingContainer(instance.Spec),
+					Volumes:                       getLicensingVolumes(instance.Spec, capabilities),
+					InitContainers:                GetLicensingInitContainers(instance.Spec, capabilities),
+					Containers:                    GetLicensingContainer(instance.Spec, capabilities),
 					TerminationGracePeriodSeconds: &resources.Seconds60,
 					ServiceAccountName:            LicensingServiceAccount,
 					ImagePullSecrets:              imagePullSecrets,
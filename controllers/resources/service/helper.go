@@ -20,6 +20,7 @@ import (
 	"context"
 
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
 	"github.com/ibm/ibm-licensing-operator/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,6 +29,7 @@ const LicensingResourceBase = "ibm-licensing-service"
 const LicensingComponentName = "ibm-licensing-service-svc"
 const LicensingReleaseName = "ibm-licensing-service"
 const LicenseServiceOCPCertName = "ibm-license-service-cert"
+const LicenseServiceCustomCertName = "ibm-licensing-certs"
 const PrometheusServiceOCPCertName = "ibm-licensing-service-prometheus-cert"
 const LicensingServiceAccount = "ibm-license-service"
 const UsageServiceName = "ibm-licensing-service-usage"
@@ -56,6 +58,20 @@ func GetServiceURL(instance *operatorv1alpha1.IBMLicensing) string {
 	return urlPrefix + GetResourceName(instance) + "." + instance.Spec.InstanceNamespace + ".svc.cluster.local:" + licensingServicePort.String()
 }
 
+// GetCertSecretName returns name of the secret with License Service certificate, empty if certificate is not mounted from secret
+func GetCertSecretName(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities) string {
+	if !instance.Spec.HTTPSEnable {
+		return ""
+	}
+	if instance.Spec.HTTPSCertsSource == operatorv1alpha1.CustomCertsSource {
+		return LicenseServiceCustomCertName
+	}
+	if capabilities.IsIssuedCertsSource(instance.Spec.HTTPSCertsSource) {
+		return LicenseServiceOCPCertName
+	}
+	return ""
+}
+
 func LabelsForSelector(instance *operatorv1alpha1.IBMLicensing) map[string]string {
 	return map[string]string{"app": GetResourceName(instance), "component": LicensingComponentName, "licensing_cr": instance.GetName()}
 }
@@ -17,6 +17,8 @@
 package service
 
 import (
+	"time"
+
 	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	"github.com/ibm/ibm-licensing-operator/controllers/resources"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +33,16 @@ const ReporterSecretTokenKeyName = "token"
 
 const URLConfigMapKey = "url"
 
+const InfoConfigMapName = "ibm-licensing-info"
+const UploadConfigMapName = "ibm-licensing-upload-config"
+
+// Keys of ConfigMaps bound with token secrets, which tell consumers when the token was rotated and until when the
+// previous token is valid
+const (
+	TokenRotatedAtConfigMapKey      = "tokenRotatedAt"
+	PreviousTokenExpiryConfigMapKey = "previousTokenExpiry"
+)
+
 func GetAPISecretToken(instance *operatorv1alpha1.IBMLicensing) (*corev1.Secret, error) {
 	return resources.GetSecretToken(instance.Spec.APISecretToken, instance.Spec.InstanceNamespace, APISecretTokenKeyName, LabelsForMeta(instance))
 }
@@ -43,11 +55,11 @@ func GetUploadConfigMap(instance *operatorv1alpha1.IBMLicensing) *corev1.ConfigM
 	metaLabels := LabelsForMeta(instance)
 	expectedCM := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "ibm-licensing-upload-config",
+			Name:      UploadConfigMapName,
 			Namespace: instance.Spec.InstanceNamespace,
 			Labels:    metaLabels,
 		},
-		Data: map[string]string{URLConfigMapKey: GetServiceURL(instance)},
+		Data: getConfigMapData(instance, APIUploadTokenName),
 	}
 	return expectedCM
 }
@@ -56,11 +68,26 @@ func GetInfoConfigMap(instance *operatorv1alpha1.IBMLicensing) *corev1.ConfigMap
 	metaLabels := LabelsForMeta(instance)
 	expectedCM := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "ibm-licensing-info",
+			Name:      InfoConfigMapName,
 			Namespace: instance.Spec.InstanceNamespace,
 			Labels:    metaLabels,
 		},
-		Data: map[string]string{URLConfigMapKey: GetServiceURL(instance)},
+		Data: getConfigMapData(instance, instance.Spec.APISecretToken),
 	}
 	return expectedCM
 }
+
+// getConfigMapData returns service URL and rotation times of token from the secret, when it is rotated
+func getConfigMapData(instance *operatorv1alpha1.IBMLicensing, tokenSecretName string) map[string]string {
+	data := map[string]string{URLConfigMapKey: GetServiceURL(instance)}
+	for _, rotation := range instance.Status.TokenRotation {
+		if rotation.SecretName != tokenSecretName {
+			continue
+		}
+		data[TokenRotatedAtConfigMapKey] = rotation.LastRotationTime.UTC().Format(time.RFC3339)
+		if rotation.PreviousTokenExpiry != nil {
+			data[PreviousTokenExpiryConfigMapKey] = rotation.PreviousTokenExpiry.UTC().Format(time.RFC3339)
+		}
+	}
+	return data
+}
@@ -38,10 +38,10 @@ var (
 	usageTargetPortName = intstr.FromString("usage-port")
 )
 
-func GetServices(instance *operatorv1alpha1.IBMLicensing) (expected []*corev1.Service, notExpected []*corev1.Service) {
-	expected = append(expected, GetLicensingService(instance))
+func GetServices(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities) (expected []*corev1.Service, notExpected []*corev1.Service) {
+	expected = append(expected, GetLicensingService(instance, capabilities))
 
-	prometheusService := GetPrometheusService(instance)
+	prometheusService := GetPrometheusService(instance, capabilities)
 	if instance.Spec.IsRHMPEnabled() {
 		expected = append(expected, prometheusService)
 	} else {
@@ -61,14 +61,14 @@ func GetLicensingServiceName(instance *operatorv1alpha1.IBMLicensing) string {
 	return GetResourceName(instance)
 }
 
-func GetLicensingService(instance *operatorv1alpha1.IBMLicensing) *corev1.Service {
+func GetLicensingService(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities) *corev1.Service {
 	metaLabels := LabelsForMeta(instance)
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        GetLicensingServiceName(instance),
 			Namespace:   instance.Spec.InstanceNamespace,
 			Labels:      metaLabels,
-			Annotations: resources.AnnotateForService(instance.Spec.HTTPSCertsSource, instance.Spec.HTTPSEnable, LicenseServiceOCPCertName),
+			Annotations: resources.AnnotateForService(capabilities, instance.Spec.HTTPSCertsSource, instance.Spec.HTTPSEnable, LicenseServiceOCPCertName),
 		},
 		Spec: corev1.ServiceSpec{
 			Type: corev1.ServiceTypeClusterIP,
@@ -93,13 +93,13 @@ func GetPrometheusServiceName() string {
 	return PrometheusServiceName
 }
 
-func GetPrometheusService(instance *operatorv1alpha1.IBMLicensing) *corev1.Service {
+func GetPrometheusService(instance *operatorv1alpha1.IBMLicensing, capabilities resources.Capabilities) *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        GetPrometheusServiceName(),
 			Namespace:   instance.Spec.InstanceNamespace,
 			Labels:      getPrometheusLabels(),
-			Annotations: resources.AnnotateForService(instance.Spec.HTTPSCertsSource, instance.Spec.HTTPSEnable, PrometheusServiceOCPCertName),
+			Annotations: resources.AnnotateForService(capabilities, instance.Spec.HTTPSCertsSource, instance.Spec.HTTPSEnable, PrometheusServiceOCPCertName),
 		},
 		Spec: corev1.ServiceSpec{
 			Type: corev1.ServiceTypeClusterIP,
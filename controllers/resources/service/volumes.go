@@ -27,8 +27,15 @@ const APIUploadTokenVolumeName = "token-upload"
 const MeteringAPICertsVolumeName = "metering-api-certs"
 const LicensingHTTPSCertsVolumeName = "licensing-https-certs"
 const PrometheusHTTPSCertsVolumeName = "prometheus-https-certs"
+const PreviousTokensVolumeName = "previous-tokens"
 
-func getLicensingVolumeMounts(spec operatorv1alpha1.IBMLicensingSpec) []corev1.VolumeMount {
+// PreviousTokensPath is the directory with previous tokens and their expiry times during the grace period of rotation
+const PreviousTokensPath = "/opt/ibm/licensing/previous-tokens/"
+
+// PreviousTokenExpirySuffix is added to the token key to name the file with expiry time of the previous token
+const PreviousTokenExpirySuffix = "-expires-at"
+
+func getLicensingVolumeMounts(spec operatorv1alpha1.IBMLicensingSpec, capabilities resources.Capabilities) []corev1.VolumeMount {
 	var volumeMounts = []corev1.VolumeMount{
 		{
 			Name:      APISecretTokenVolumeName,
@@ -43,8 +50,16 @@ func getLicensingVolumeMounts(spec operatorv1alpha1.IBMLicensingSpec) []corev1.V
 			ReadOnly:  true,
 		},
 	}
+	if spec.TokenRotation != nil {
+		// directory is mounted without subPath, so that kubelet removes previous tokens when they expire
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      PreviousTokensVolumeName,
+			MountPath: PreviousTokensPath,
+			ReadOnly:  true,
+		})
+	}
 	if spec.HTTPSEnable {
-		if spec.HTTPSCertsSource == operatorv1alpha1.CustomCertsSource || (resources.IsServiceCAAPI && spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource) {
+		if spec.HTTPSCertsSource == operatorv1alpha1.CustomCertsSource || capabilities.IsIssuedCertsSource(spec.HTTPSCertsSource) {
 			volumeMounts = append(volumeMounts, []corev1.VolumeMount{
 				{
 					Name:      LicensingHTTPSCertsVolumeName,
@@ -76,7 +91,7 @@ func getLicensingVolumeMounts(spec operatorv1alpha1.IBMLicensingSpec) []corev1.V
 	return volumeMounts
 }
 
-func getLicensingVolumes(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Volume {
+func getLicensingVolumes(spec operatorv1alpha1.IBMLicensingSpec, capabilities resources.Capabilities) []corev1.Volume {
 	var volumes []corev1.Volume
 
 	apiSecretTokenVolume := corev1.Volume{
@@ -103,6 +118,10 @@ func getLicensingVolumes(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Volume
 
 	volumes = append(volumes, apiUploadTokenVolume)
 
+	if spec.TokenRotation != nil {
+		volumes = append(volumes, getPreviousTokensVolume(spec))
+	}
+
 	if spec.IsMetering() {
 		meteringAPICertVolume := corev1.Volume{
 			Name: MeteringAPICertsVolumeName,
@@ -120,8 +139,8 @@ func getLicensingVolumes(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Volume
 
 	if spec.HTTPSEnable {
 		if spec.HTTPSCertsSource == operatorv1alpha1.CustomCertsSource {
-			volumes = append(volumes, resources.GetVolume(LicensingHTTPSCertsVolumeName, "ibm-licensing-certs"))
-		} else if resources.IsServiceCAAPI && spec.HTTPSCertsSource == operatorv1alpha1.OcpCertsSource {
+			volumes = append(volumes, resources.GetVolume(LicensingHTTPSCertsVolumeName, LicenseServiceCustomCertName))
+		} else if capabilities.IsIssuedCertsSource(spec.HTTPSCertsSource) {
 			volumes = append(volumes, resources.GetVolume(LicensingHTTPSCertsVolumeName, LicenseServiceOCPCertName))
 			if spec.IsRHMPEnabled() {
 				volumes = append(volumes, resources.GetVolume(PrometheusHTTPSCertsVolumeName, PrometheusServiceOCPCertName))
@@ -131,3 +150,39 @@ func getLicensingVolumes(spec operatorv1alpha1.IBMLicensingSpec) []corev1.Volume
 
 	return volumes
 }
+
+// getPreviousTokensVolume returns volume with previous tokens, named by keys of current tokens, and their expiry times,
+// files are optional, as they exist only during the grace period of rotation
+func getPreviousTokensVolume(spec operatorv1alpha1.IBMLicensingSpec) corev1.Volume {
+	var sources []corev1.VolumeProjection
+	for _, token := range []struct{ secretName, configMapName, key string }{
+		{spec.APISecretToken, InfoConfigMapName, APISecretTokenKeyName},
+		{APIUploadTokenName, UploadConfigMapName, APIUploadTokenKeyName},
+	} {
+		sources = append(sources, []corev1.VolumeProjection{
+			{
+				Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: token.secretName},
+					Items:                []corev1.KeyToPath{{Key: token.key + resources.PreviousTokenKeySuffix, Path: token.key}},
+					Optional:             &resources.TrueVar,
+				},
+			},
+			{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: token.configMapName},
+					Items:                []corev1.KeyToPath{{Key: PreviousTokenExpiryConfigMapKey, Path: token.key + PreviousTokenExpirySuffix}},
+					Optional:             &resources.TrueVar,
+				},
+			},
+		}...)
+	}
+	return corev1.Volume{
+		Name: PreviousTokensVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources:     sources,
+				DefaultMode: &resources.DefaultSecretMode,
+			},
+		},
+	}
+}
@@ -0,0 +1,211 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	operatorv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/service"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Command is the operator subcommand run by audit snapshot jobs
+const Command = "audit-snapshot"
+
+// OperatorBinary is the operator executable in the operator image
+const OperatorBinary = "ibm-licensing-operator"
+
+// AuditSnapshotLabel is set on jobs to the name of IBMLicensingAuditSnapshot
+const AuditSnapshotLabel = "operator.ibm.com/audit-snapshot"
+
+// ScheduleTimeAnnotation is set on jobs to the time for which the run was scheduled
+const ScheduleTimeAnnotation = "operator.ibm.com/schedule-time"
+
+// Environment variables passing secrets to audit snapshot jobs
+const (
+	TokenEnvVar             = "LICENSING_API_TOKEN" // #nosec
+	S3AccessKeyIDEnvVar     = "S3_ACCESS_KEY_ID"
+	S3SecretAccessKeyEnvVar = "S3_SECRET_ACCESS_KEY" // #nosec
+	SigningKeyEnvVar        = "SIGNING_KEY"
+)
+
+// Keys of S3 credentials secret
+const (
+	S3AccessKeyIDKey     = "accessKeyID"
+	S3SecretAccessKeyKey = "secretAccessKey" // #nosec
+)
+
+// VolumeMountPath is the directory where persistent volume is mounted in audit snapshot jobs
+const VolumeMountPath = "/snapshots"
+
+const containerName = "audit-snapshot"
+const volumeName = "snapshots"
+const maxJobBaseNameLength = 52
+const jobDeadlineSeconds = int64(30 * 60)
+
+// archiveTimeLayout formats schedule time in archive names
+const archiveTimeLayout = "20060102-150405"
+
+var (
+	jobCPURequest    = resource.MustParse("50m")
+	jobCPULimit      = resource.MustParse("200m")
+	jobMemoryRequest = resource.MustParse("64Mi")
+	jobMemoryLimit   = resource.MustParse("512Mi")
+)
+
+func LabelsForJob(instance *operatorv1alpha1.IBMLicensingAuditSnapshot) map[string]string {
+	return map[string]string{AuditSnapshotLabel: instance.GetName(), "app.kubernetes.io/managed-by": "operator"}
+}
+
+// GetJobName returns name of job of the run scheduled at given time
+func GetJobName(instance *operatorv1alpha1.IBMLicensingAuditSnapshot, scheduleTime time.Time) string {
+	baseName := instance.GetName()
+	if len(baseName) > maxJobBaseNameLength {
+		baseName = strings.TrimRight(baseName[:maxJobBaseNameLength], "-.")
+	}
+	return baseName + "-" + strconv.FormatInt(scheduleTime.Unix()/60, 10)
+}
+
+// GetJob returns job retrieving audit snapshot from License Service of licensing instance with filled default values,
+// the job runs the operator image with audit snapshot subcommand
+func GetJob(instance *operatorv1alpha1.IBMLicensingAuditSnapshot, licensing *operatorv1alpha1.IBMLicensing, image string,
+	scheduleTime time.Time) *batchv1.Job {
+	spec := instance.Spec
+	start, end := spec.GetDates(scheduleTime)
+	args := []string{
+		Command,
+		"--url", service.GetServiceURL(licensing) + service.SnapshotPath,
+		"--name", GetArchiveName(scheduleTime.UTC().Format(archiveTimeLayout)),
+		"--retention", strconv.Itoa(int(spec.GetRetention())),
+	}
+	if start != "" {
+		args = append(args, "--start", start)
+	}
+	if end != "" {
+		args = append(args, "--end", end)
+	}
+	env := []corev1.EnvVar{secretEnvVar(TokenEnvVar, licensing.Spec.APISecretToken, service.APISecretTokenKeyName)}
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	if pvc := spec.Storage.PVC; pvc != nil {
+		args = append(args, "--pvc-claim", pvc.ClaimName, "--pvc-path", pvc.Path)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.ClaimName},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: VolumeMountPath})
+	}
+	if s3 := spec.Storage.S3; s3 != nil {
+		args = append(args, "--s3-endpoint", s3.Endpoint, "--s3-bucket", s3.Bucket, "--s3-region", s3.GetRegion(),
+			"--s3-prefix", s3.Prefix)
+		env = append(env,
+			secretEnvVar(S3AccessKeyIDEnvVar, s3.CredentialsSecret, S3AccessKeyIDKey),
+			secretEnvVar(S3SecretAccessKeyEnvVar, s3.CredentialsSecret, S3SecretAccessKeyKey))
+	}
+	if spec.SigningKeySecret != "" {
+		env = append(env, secretEnvVar(SigningKeyEnvVar, spec.SigningKeySecret, SigningKeyKey))
+	}
+
+	var imagePullSecrets []corev1.LocalObjectReference
+	for _, pullSecret := range licensing.Spec.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: pullSecret})
+	}
+	backoffLimit := int32(0)
+	deadline := jobDeadlineSeconds
+	securityContext := resources.GetSecurityContext()
+	securityContext.ReadOnlyRootFilesystem = &resources.TrueVar
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GetJobName(instance, scheduleTime),
+			Namespace:   instance.GetNamespace(),
+			Labels:      LabelsForJob(instance),
+			Annotations: map[string]string{ScheduleTimeAnnotation: scheduleTime.UTC().Format(time.RFC3339)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      LabelsForJob(instance),
+					Annotations: resources.AnnotationsForPod(),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: imagePullSecrets,
+					Volumes:          volumes,
+					Containers: []corev1.Container{
+						{
+							Name:                     containerName,
+							Image:                    image,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							Command:                  []string{OperatorBinary},
+							Args:                     args,
+							Env:                      env,
+							VolumeMounts:             volumeMounts,
+							SecurityContext:          securityContext,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: jobCPURequest, corev1.ResourceMemory: jobMemoryRequest},
+								Limits:   corev1.ResourceList{corev1.ResourceCPU: jobCPULimit, corev1.ResourceMemory: jobMemoryLimit},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func secretEnvVar(name string, secretName string, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// GetJobResult returns result of finished job from termination message of its pod, ok is false when the message
+// does not contain the result, f.e. when the container was killed
+func GetJobResult(pods []corev1.Pod) (result Result, ok bool) {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != containerName || status.State.Terminated == nil {
+				continue
+			}
+			if err := json.Unmarshal([]byte(status.State.Terminated.Message), &result); err == nil {
+				return result, true
+			}
+			return Result{Error: strings.TrimSpace(status.State.Terminated.Message)}, false
+		}
+	}
+	return Result{}, false
+}
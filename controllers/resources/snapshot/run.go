@@ -0,0 +1,104 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+
+	"github.com/ibm/ibm-licensing-operator/controllers/resources"
+)
+
+// maxSnapshotSize limits size of audit snapshot archive, which is kept in memory of the job
+const maxSnapshotSize = 256 * 1024 * 1024
+
+// Options of a single audit snapshot run
+type Options struct {
+	// URL of License Service snapshot API
+	URL string
+	// License Service API token
+	Token string
+	// First and last day covered by audit snapshot, License Service default range is used when empty
+	Start string
+	End   string
+	// Name of stored archive
+	Name string
+	// Number of archives kept in the storage
+	Retention int
+	Storage   Storage
+	// PEM encoded key signing manifest of the archive, the archive is stored without manifest and signature when empty
+	SigningKey []byte
+}
+
+// Result of a single audit snapshot run, passed from the job to the operator in termination message
+type Result struct {
+	Location  string `json:"location,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Take retrieves audit snapshot from License Service, stores it with signed manifest when signing key is set and deletes
+// archives exceeding retention
+func Take(options Options) (Result, error) {
+	query := url.Values{"token": {options.Token}}
+	if options.Start != "" {
+		query.Set("start", options.Start)
+	}
+	if options.End != "" {
+		query.Set("end", options.End)
+	}
+	var signingKey crypto.Signer
+	if len(options.SigningKey) > 0 {
+		var err error
+		if signingKey, err = ParsePrivateKey(options.SigningKey); err != nil {
+			return Result{}, err
+		}
+	}
+	data, err := resources.CallOperandAPI(options.URL, query, maxSnapshotSize)
+	if err != nil {
+		return Result{}, err
+	}
+	sum := sha256.Sum256(data)
+	var manifest, signature []byte
+	if signingKey != nil {
+		manifest = GetManifest(options.Name, data)
+		if signature, err = Sign(signingKey, manifest); err != nil {
+			return Result{}, err
+		}
+	}
+	location, err := options.Storage.Put(options.Name, data)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Location: location, Size: int64(len(data)), Checksum: hex.EncodeToString(sum[:])}
+	if signature != nil {
+		if _, err := options.Storage.Put(options.Name+ManifestSuffix, manifest); err != nil {
+			return result, err
+		}
+		if result.Signature, err = options.Storage.Put(options.Name+SignatureSuffix, signature); err != nil {
+			return result, err
+		}
+	}
+	if err := Prune(options.Storage, options.Retention); err != nil {
+		result.Error = "archive stored, but old archives were not deleted: " + err.Error()
+	}
+	return result, nil
+}
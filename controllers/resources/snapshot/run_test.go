@@ -0,0 +1,98 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestTake(t *testing.T) {
+	archive := []byte("audit snapshot archive")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("token") != "api-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if query.Get("start") != "2021-05-01" || query.Get("end") != "2021-05-31" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	storage := &VolumeStorage{MountPath: t.TempDir(), Path: "licensing", ClaimName: "audit-snapshots"}
+	if _, err := storage.Put(GetArchiveName("20210501-020000"), []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	name := GetArchiveName("20210601-020000")
+	options := Options{
+		URL:        server.URL,
+		Token:      "api-token",
+		Start:      "2021-05-01",
+		End:        "2021-05-31",
+		Name:       name,
+		Retention:  1,
+		Storage:    storage,
+		SigningKey: generateSigningKeys(t)["ed25519"],
+	}
+
+	result, err := Take(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(archive)
+	expected := Result{
+		Location:  "audit-snapshots:/licensing/" + name,
+		Size:      int64(len(archive)),
+		Checksum:  hex.EncodeToString(sum[:]),
+		Signature: "audit-snapshots:/licensing/" + name + SignatureSuffix,
+	}
+	if result != expected {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+	names, err := storage.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 3 {
+		t.Errorf("expected archive with manifest and signature and old archive deleted, got %v", names)
+	}
+	manifest, err := ioutil.ReadFile(filepath.Join(storage.MountPath, storage.Path, name+ManifestSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(manifest) != string(GetManifest(name, archive)) {
+		t.Errorf("unexpected manifest %s", manifest)
+	}
+
+	options.Token = "invalid-token"
+	options.Name = GetArchiveName("20210701-020000")
+	if result, err := Take(options); err == nil {
+		t.Errorf("expected unauthorized snapshot to fail, got %+v", result)
+	}
+	if names, _ := storage.List(); len(names) != 3 {
+		t.Errorf("expected nothing stored by failed snapshot, got %v", names)
+	}
+}
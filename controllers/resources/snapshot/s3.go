@@ -0,0 +1,205 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const s3Service = "s3"
+const s3Algorithm = "AWS4-HMAC-SHA256"
+const s3Timeout = 5 * time.Minute
+
+// S3Storage keeps archives in a bucket of S3-compatible object storage, requests use path-style addressing and are
+// signed with AWS Signature Version 4
+type S3Storage struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// listBucketResult is the part of ListObjectsV2 response used to list archives
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Storage) Put(name string, data []byte) (string, error) {
+	key := s.Prefix + name
+	if _, err := s.do(http.MethodPut, key, url.Values{}, data); err != nil {
+		return "", err
+	}
+	return s.objectURL(key), nil
+}
+
+func (s *S3Storage) List() ([]string, error) {
+	var names []string
+	query := url.Values{"list-type": {"2"}, "prefix": {s.Prefix}}
+	for {
+		body, err := s.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		result := listBucketResult{}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse objects of bucket %s: %w", s.Bucket, err)
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, s.Prefix)
+			// objects in nested prefixes are not archives of this storage
+			if !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated {
+			return names, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (s *S3Storage) Delete(name string) error {
+	_, err := s.do(http.MethodDelete, s.Prefix+name, url.Values{}, nil)
+	return err
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	return strings.TrimSuffix(s.Endpoint, "/") + s.canonicalURI(key)
+}
+
+// canonicalURI returns path of the bucket or of the object in it
+func (s *S3Storage) canonicalURI(key string) string {
+	uri := "/" + uriEncode(s.Bucket, true)
+	if key != "" {
+		uri += "/" + uriEncode(key, false)
+	}
+	return uri
+}
+
+// do sends signed request and returns response body
+func (s *S3Storage) do(method string, key string, query url.Values, body []byte) ([]byte, error) {
+	request, err := http.NewRequest(method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.URL.RawQuery = canonicalQuery(query)
+	s.sign(request, s.canonicalURI(key), body, time.Now().UTC())
+
+	response, err := (&http.Client{Timeout: s3Timeout}).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s failed with status %s: %s", method, s.objectURL(key), response.Status, string(responseBody))
+	}
+	return responseBody, nil
+}
+
+// sign adds headers of AWS Signature Version 4 to the request
+func (s *S3Storage) sign(request *http.Request, canonicalURI string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+	request.Header.Set("x-amz-date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI,
+		request.URL.RawQuery,
+		"host:" + request.URL.Host + "\n" + "x-amz-content-sha256:" + payloadHash + "\n" + "x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + s.Region + "/" + s3Service + "/aws4_request"
+	stringToSign := s3Algorithm + "\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), day)
+	signingKey = hmacSHA256(signingKey, s.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", s3Algorithm+" Credential="+s.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery encodes query sorted by keys, with spaces encoded as %20 as required by the signature
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode encodes all characters except unreserved ones, slashes are kept unless encodeSlash is true
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
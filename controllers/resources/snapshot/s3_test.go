@@ -0,0 +1,125 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBucket serves objects of a single bucket with path-style addressing, listing returns pageSize keys per page
+type fakeBucket struct {
+	bucket   string
+	pageSize int
+
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), s3Algorithm+" Credential=access-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+b.bucket), "/")
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		b.list(w, r)
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		b.objects[key] = data
+	case r.Method == http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *fakeBucket) list(w http.ResponseWriter, r *http.Request) {
+	var keys []string
+	for key := range b.objects {
+		if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+	end := start + b.pageSize
+	result := listBucketResult{}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	} else {
+		end = len(keys)
+	}
+	for _, key := range keys[start:end] {
+		result.Contents = append(result.Contents, struct {
+			Key string `xml:"Key"`
+		}{Key: key})
+	}
+	output, _ := xml.Marshal(result)
+	_, _ = w.Write(output)
+}
+
+func TestS3StoragePrune(t *testing.T) {
+	bucket := &fakeBucket{bucket: "license-audit", pageSize: 2, objects: map[string][]byte{
+		"other/" + GetArchiveName("20210101-020000"): nil,
+	}}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+	storage := &S3Storage{Endpoint: server.URL, Bucket: "license-audit", Region: "us-east-1", Prefix: "cluster-a/",
+		AccessKeyID: "access-key", SecretAccessKey: "secret-key"}
+
+	for _, name := range []string{
+		GetArchiveName("20210101-020000"),
+		GetArchiveName("20210101-020000") + SignatureSuffix,
+		GetArchiveName("20210201-020000"),
+		GetArchiveName("20210301-020000"),
+	} {
+		location, err := storage.Put(name, []byte(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := server.URL + "/license-audit/cluster-a/" + name; location != expected {
+			t.Errorf("expected location %s, got %s", expected, location)
+		}
+	}
+	if err := Prune(storage, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for key := range bucket.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	expected := []string{"cluster-a/" + GetArchiveName("20210301-020000"), "other/" + GetArchiveName("20210101-020000")}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
@@ -0,0 +1,158 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SigningKeyKey is the key of signing key secret holding PEM encoded private key
+const SigningKeyKey = "privateKey"
+
+// Suffixes of names of manifest and detached signature stored next to audit snapshot archive
+const (
+	ManifestSuffix  = ".sha256"
+	SignatureSuffix = ".sig"
+)
+
+// minRSAKeyBits is the minimal size of RSA signing keys
+const minRSAKeyBits = 2048
+
+// ParsePrivateKey parses PEM encoded ed25519 or RSA private key in PKCS #8 or PKCS #1 format
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key has unsupported PEM type %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch signer := key.(type) {
+	case ed25519.PrivateKey:
+		return signer, nil
+	case *rsa.PrivateKey:
+		if signer.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA signing key must have at least %d bits", minRSAKeyBits)
+		}
+		return signer, nil
+	default:
+		return nil, errors.New("signing key must be ed25519 or RSA key")
+	}
+}
+
+// ParsePublicKey parses PEM encoded ed25519 or RSA public key, certificate or private key, from which the public key is taken
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+	var key crypto.PublicKey
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var certificate *x509.Certificate
+		if certificate, err = x509.ParseCertificate(block.Bytes); err == nil {
+			key = certificate.PublicKey
+		}
+	default:
+		var signer crypto.Signer
+		if signer, err = ParsePrivateKey(data); err == nil {
+			key = signer.Public()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case ed25519.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, errors.New("public key must be ed25519 or RSA key")
+	}
+}
+
+// GetManifest returns SHA-256 manifest of archive in the format of sha256sum
+func GetManifest(name string, data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return []byte(hex.EncodeToString(sum[:]) + "  " + name + "\n")
+}
+
+// Sign returns detached signature of manifest, ed25519 signature or RSA PKCS #1 v1.5 signature of SHA-256 digest
+func Sign(key crypto.Signer, manifest []byte) ([]byte, error) {
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, manifest, crypto.Hash(0))
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(manifest)
+		return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, errors.New("signing key must be ed25519 or RSA key")
+	}
+}
+
+// Verify checks that signature of manifest is valid and that manifest lists checksum of archive
+func Verify(key crypto.PublicKey, archive []byte, manifest []byte, signature []byte) error {
+	switch publicKey := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, manifest, signature) {
+			return errors.New("signature of manifest is invalid")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(manifest)
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("signature of manifest is invalid")
+		}
+	default:
+		return errors.New("public key must be ed25519 or RSA key")
+	}
+
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && strings.EqualFold(fields[0], checksum) {
+			return nil
+		}
+	}
+	return fmt.Errorf("archive checksum %s is not listed in manifest, the archive was modified", checksum)
+}
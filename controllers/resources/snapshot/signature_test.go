@@ -0,0 +1,176 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func generateSigningKeys(t *testing.T) map[string][]byte {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Bytes, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string][]byte{
+		"ed25519": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ed25519Bytes}),
+		"rsa":     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+	}
+}
+
+func signArchive(t *testing.T, privateKeyPEM []byte, name string, archive []byte) (crypto.PublicKey, []byte, []byte) {
+	signer, err := ParsePrivateKey(privateKeyPEM)
+	if err != nil {
+		t.Fatalf("failed to parse private key: %v", err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	manifest := GetManifest(name, archive)
+	signature, err := Sign(signer, manifest)
+	if err != nil {
+		t.Fatalf("failed to sign manifest: %v", err)
+	}
+	return publicKey, manifest, signature
+}
+
+func TestSignAndVerify(t *testing.T) {
+	archive := []byte("audit snapshot archive")
+	for keyType, privateKeyPEM := range generateSigningKeys(t) {
+		t.Run(keyType, func(t *testing.T) {
+			publicKey, manifest, signature := signArchive(t, privateKeyPEM, "snapshot.zip", archive)
+			if err := Verify(publicKey, archive, manifest, signature); err != nil {
+				t.Errorf("expected signed archive to be verified, got %v", err)
+			}
+
+			// public key can also be taken from the private key
+			publicKey, err := ParsePublicKey(privateKeyPEM)
+			if err != nil {
+				t.Fatalf("failed to parse public key from private key: %v", err)
+			}
+			if err := Verify(publicKey, archive, manifest, signature); err != nil {
+				t.Errorf("expected signed archive to be verified with public key from private key, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	archive := []byte("audit snapshot archive")
+	keys := generateSigningKeys(t)
+	for keyType, privateKeyPEM := range keys {
+		publicKey, manifest, signature := signArchive(t, privateKeyPEM, "snapshot.zip", archive)
+		otherKeyType := "rsa"
+		if keyType == "rsa" {
+			otherKeyType = "ed25519"
+		}
+		otherPublicKey, err := ParsePublicKey(keys[otherKeyType])
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherSigner, err := ParsePrivateKey(keys[otherKeyType])
+		if err != nil {
+			t.Fatal(err)
+		}
+		// manifest of modified archive signed with the key of another signer
+		otherManifest := GetManifest("snapshot.zip", []byte("modified archive"))
+		otherSignature, err := Sign(otherSigner, otherManifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name      string
+			publicKey crypto.PublicKey
+			archive   []byte
+			manifest  []byte
+			signature []byte
+		}{
+			{
+				name:      "modified archive",
+				publicKey: publicKey,
+				archive:   []byte("modified archive"),
+				manifest:  manifest,
+				signature: signature,
+			},
+			{
+				name:      "modified manifest",
+				publicKey: publicKey,
+				archive:   []byte("modified archive"),
+				manifest:  otherManifest,
+				signature: signature,
+			},
+			{
+				name:      "modified signature",
+				publicKey: publicKey,
+				archive:   archive,
+				manifest:  manifest,
+				signature: append(append([]byte{}, signature[:len(signature)-1]...), signature[len(signature)-1]^0xff),
+			},
+			{
+				name:      "signature of another key",
+				publicKey: publicKey,
+				archive:   []byte("modified archive"),
+				manifest:  otherManifest,
+				signature: otherSignature,
+			},
+			{
+				name:      "public key of another signer",
+				publicKey: otherPublicKey,
+				archive:   archive,
+				manifest:  manifest,
+				signature: signature,
+			},
+		}
+		for _, test := range tests {
+			t.Run(keyType+"/"+test.name, func(t *testing.T) {
+				if err := Verify(test.publicKey, test.archive, test.manifest, test.signature); err == nil {
+					t.Error("expected verification to fail")
+				}
+			})
+		}
+	}
+}
+
+func TestParsePrivateKeyRejectsShortRSAKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	if _, err := ParsePrivateKey(privateKeyPEM); err == nil {
+		t.Error("expected RSA key shorter than 2048 bits to be rejected")
+	}
+}
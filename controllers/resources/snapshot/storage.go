@@ -0,0 +1,123 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const archivePrefix = "snapshot-"
+const archiveSuffix = ".zip"
+
+// Storage keeps audit snapshot archives
+type Storage interface {
+	// Put stores archive under given name and returns its location
+	Put(name string, data []byte) (string, error)
+	// List returns names of stored archives
+	List() ([]string, error)
+	// Delete removes archive with given name
+	Delete(name string) error
+}
+
+// GetArchiveName returns name of archive of audit snapshot scheduled at given time, archive names sort by time
+func GetArchiveName(scheduleTime string) string {
+	return archivePrefix + scheduleTime + archiveSuffix
+}
+
+func isArchiveName(name string) bool {
+	return strings.HasPrefix(name, archivePrefix) && strings.HasSuffix(name, archiveSuffix)
+}
+
+// Prune deletes the oldest archives together with their manifests and signatures, so that retention archives are kept
+func Prune(storage Storage, retention int) error {
+	names, err := storage.List()
+	if err != nil {
+		return err
+	}
+	var archives []string
+	stored := map[string]bool{}
+	for _, name := range names {
+		stored[name] = true
+		if isArchiveName(name) {
+			archives = append(archives, name)
+		}
+	}
+	sort.Strings(archives)
+	for len(archives) > retention {
+		for _, name := range []string{archives[0] + ManifestSuffix, archives[0] + SignatureSuffix, archives[0]} {
+			if !stored[name] {
+				continue
+			}
+			if err := storage.Delete(name); err != nil {
+				return err
+			}
+		}
+		archives = archives[1:]
+	}
+	return nil
+}
+
+// VolumeStorage keeps archives in a directory of mounted persistent volume
+type VolumeStorage struct {
+	// Directory where the volume is mounted
+	MountPath string
+	// Directory in the volume where archives are stored
+	Path string
+	// Name of persistent volume claim, used in locations of archives
+	ClaimName string
+}
+
+// dir returns directory of archives, path is resolved from the root of the volume, so that it does not leave the volume
+func (s *VolumeStorage) dir() string {
+	return filepath.Join(s.MountPath, filepath.Join("/", s.Path))
+}
+
+func (s *VolumeStorage) Put(name string, data []byte) (string, error) {
+	if err := os.MkdirAll(s.dir(), 0750); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(s.dir(), name), data, 0640); err != nil {
+		return "", err
+	}
+	return s.ClaimName + ":" + path.Join("/", s.Path, name), nil
+}
+
+func (s *VolumeStorage) List() ([]string, error) {
+	files, err := ioutil.ReadDir(s.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() {
+			names = append(names, file.Name())
+		}
+	}
+	return names, nil
+}
+
+func (s *VolumeStorage) Delete(name string) error {
+	return os.Remove(filepath.Join(s.dir(), name))
+}
@@ -0,0 +1,109 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	stored := []string{
+		GetArchiveName("20210101-020000"),
+		GetArchiveName("20210101-020000") + ManifestSuffix,
+		GetArchiveName("20210101-020000") + SignatureSuffix,
+		GetArchiveName("20210201-020000"),
+		GetArchiveName("20210301-020000"),
+		GetArchiveName("20210301-020000") + ManifestSuffix,
+		GetArchiveName("20210301-020000") + SignatureSuffix,
+		GetArchiveName("20210401-020000"),
+		"notes.txt",
+	}
+	tests := []struct {
+		name      string
+		retention int
+		expected  []string
+	}{
+		{
+			name:      "archives within retention",
+			retention: 4,
+			expected:  stored,
+		},
+		{
+			name:      "oldest archives with manifests and signatures deleted",
+			retention: 2,
+			expected: []string{
+				GetArchiveName("20210301-020000"),
+				GetArchiveName("20210301-020000") + ManifestSuffix,
+				GetArchiveName("20210301-020000") + SignatureSuffix,
+				GetArchiveName("20210401-020000"),
+				"notes.txt",
+			},
+		},
+		{
+			name:      "all archives deleted",
+			retention: 0,
+			expected:  []string{"notes.txt"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storage := &VolumeStorage{MountPath: t.TempDir(), Path: "licensing", ClaimName: "audit-snapshots"}
+			for _, name := range stored {
+				if _, err := storage.Put(name, []byte(name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := Prune(storage, test.retention); err != nil {
+				t.Fatal(err)
+			}
+			names, err := storage.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := append([]string{}, test.expected...)
+			sort.Strings(names)
+			sort.Strings(expected)
+			if !reflect.DeepEqual(names, expected) {
+				t.Errorf("expected %v, got %v", expected, names)
+			}
+		})
+	}
+}
+
+func TestVolumeStorageStaysInVolume(t *testing.T) {
+	root := t.TempDir()
+	mountPath := filepath.Join(root, "volume")
+	storage := &VolumeStorage{MountPath: mountPath, Path: "../../outside", ClaimName: "audit-snapshots"}
+	location, err := storage.Put("snapshot.zip", []byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "audit-snapshots:/outside/snapshot.zip" {
+		t.Errorf("unexpected location %s", location)
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(mountPath, "outside", "snapshot.zip")); err != nil {
+		t.Errorf("expected archive in the volume: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "outside")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing stored outside of the volume, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"time"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations of token secrets with times of rotation, stored in RFC 3339 format
+const (
+	TokenRotatedAtAnnotation      = "operator.ibm.com/token-rotated-at"
+	PreviousTokenExpiryAnnotation = "operator.ibm.com/previous-token-expires-at"
+)
+
+// PreviousTokenKeySuffix is added to the token key to store the previous token during the grace period
+const PreviousTokenKeySuffix = "-previous"
+
+const tokenLength = 24
+
+// RotateSecretToken rotates token stored under the key of the secret when the rotation interval passed since the last
+// rotation, the previous token is kept under the key with PreviousTokenKeySuffix until the grace period ends,
+// returns true when the secret was changed and rotation status, which is nil when rotation is not configured
+func RotateSecretToken(secret *corev1.Secret, key string, rotation *v1alpha1.IBMLicensingTokenRotation,
+	now time.Time) (bool, *v1alpha1.TokenRotationStatus, error) {
+	changed := false
+	previousKey := key + PreviousTokenKeySuffix
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	// previous token is retired also when rotation was disabled during the grace period
+	previousTokenExpiry, hasPreviousToken := getAnnotationTime(secret, PreviousTokenExpiryAnnotation)
+	if hasPreviousToken && !now.Before(previousTokenExpiry) {
+		delete(secret.Data, previousKey)
+		delete(secret.Annotations, PreviousTokenExpiryAnnotation)
+		hasPreviousToken = false
+		changed = true
+	}
+	if rotation == nil {
+		return changed, nil, nil
+	}
+
+	rotatedAt, found := getAnnotationTime(secret, TokenRotatedAtAnnotation)
+	if !found {
+		// token generated before rotation was configured is as old as the secret
+		rotatedAt = secret.GetCreationTimestamp().Time
+		setAnnotationTime(secret, TokenRotatedAtAnnotation, rotatedAt)
+		changed = true
+	}
+	if !now.Before(rotatedAt.Add(rotation.Interval.Duration)) {
+		token, err := RandString(tokenLength)
+		if err != nil {
+			return false, nil, err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[previousKey] = secret.Data[key]
+		secret.Data[key] = []byte(token)
+		// times are stored in annotations with second precision
+		rotatedAt = now.Truncate(time.Second)
+		previousTokenExpiry = rotatedAt.Add(rotation.GetGracePeriod())
+		hasPreviousToken = true
+		setAnnotationTime(secret, TokenRotatedAtAnnotation, rotatedAt)
+		setAnnotationTime(secret, PreviousTokenExpiryAnnotation, previousTokenExpiry)
+		changed = true
+	}
+
+	status := &v1alpha1.TokenRotationStatus{
+		SecretName:       secret.GetName(),
+		LastRotationTime: metav1.Time{Time: rotatedAt},
+		NextRotationTime: metav1.Time{Time: rotatedAt.Add(rotation.Interval.Duration)},
+	}
+	if hasPreviousToken {
+		status.PreviousTokenExpiry = &metav1.Time{Time: previousTokenExpiry}
+	}
+	return changed, status, nil
+}
+
+// GetTokenRotationDelay returns time until the earliest rotation or retirement of the previous token, zero when
+// tokens are not rotated
+func GetTokenRotationDelay(statuses []v1alpha1.TokenRotationStatus, now time.Time) time.Duration {
+	var times []time.Time
+	for _, status := range statuses {
+		times = append(times, status.NextRotationTime.Time)
+		if status.PreviousTokenExpiry != nil {
+			times = append(times, status.PreviousTokenExpiry.Time)
+		}
+	}
+	return getDelay(times, now)
+}
+
+// getDelay returns time until the earliest of times, at least one second, zero when there are no times
+func getDelay(times []time.Time, now time.Time) time.Duration {
+	var delay time.Duration
+	for i, t := range times {
+		if untilTime := t.Sub(now); i == 0 || untilTime < delay {
+			delay = untilTime
+		}
+	}
+	if len(times) > 0 && delay < time.Second {
+		delay = time.Second
+	}
+	return delay
+}
+
+func getAnnotationTime(secret *corev1.Secret, annotation string) (time.Time, bool) {
+	value, found := secret.Annotations[annotation]
+	if !found {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	return parsed, err == nil
+}
+
+func setAnnotationTime(secret *corev1.Secret, annotation string, t time.Time) {
+	secret.Annotations[annotation] = t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,219 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const rotationKey = "token"
+
+var (
+	rotationCreated   = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	testTokenRotation = &v1alpha1.IBMLicensingTokenRotation{
+		Interval:    metav1.Duration{Duration: 720 * time.Hour},
+		GracePeriod: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+)
+
+func newTokenSecret(annotations map[string]string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ibm-licensing-token",
+			CreationTimestamp: metav1.NewTime(rotationCreated),
+			Annotations:       annotations,
+		},
+		Data: data,
+	}
+}
+
+func rotationTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func TestRotateSecretTokenWithoutRotation(t *testing.T) {
+	secret := newTokenSecret(nil, map[string][]byte{rotationKey: []byte("current")})
+	changed, status, err := RotateSecretToken(secret, rotationKey, nil, rotationCreated.Add(1000*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || status != nil {
+		t.Errorf("expected secret without rotation not to be changed, got changed=%v status=%v", changed, status)
+	}
+	if string(secret.Data[rotationKey]) != "current" {
+		t.Errorf("expected token not to be rotated, got %q", secret.Data[rotationKey])
+	}
+}
+
+func TestRotateSecretTokenBeforeInterval(t *testing.T) {
+	secret := newTokenSecret(nil, map[string][]byte{rotationKey: []byte("current")})
+	now := rotationCreated.Add(100 * time.Hour)
+	changed, status, err := RotateSecretToken(secret, rotationKey, testTokenRotation, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// token created before rotation was configured is as old as the secret
+	if !changed || secret.Annotations[TokenRotatedAtAnnotation] != rotationTime(rotationCreated) {
+		t.Errorf("expected rotation time to be initialized with creation time, got changed=%v annotations=%v",
+			changed, secret.Annotations)
+	}
+	if string(secret.Data[rotationKey]) != "current" {
+		t.Errorf("expected token not to be rotated before interval, got %q", secret.Data[rotationKey])
+	}
+	if !status.LastRotationTime.Time.Equal(rotationCreated) || !status.NextRotationTime.Time.Equal(rotationCreated.Add(720*time.Hour)) ||
+		status.PreviousTokenExpiry != nil || status.SecretName != secret.GetName() {
+		t.Errorf("unexpected rotation status %+v", status)
+	}
+
+	changed, _, err = RotateSecretToken(secret, rotationKey, testTokenRotation, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected secret not to be changed again before interval")
+	}
+}
+
+func TestRotateSecretTokenAfterInterval(t *testing.T) {
+	secret := newTokenSecret(map[string]string{TokenRotatedAtAnnotation: rotationTime(rotationCreated)},
+		map[string][]byte{rotationKey: []byte("current")})
+	now := rotationCreated.Add(720*time.Hour + 1500*time.Millisecond)
+	changed, status, err := RotateSecretToken(secret, rotationKey, testTokenRotation, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected token to be rotated")
+	}
+	token := string(secret.Data[rotationKey])
+	if token == "current" || len(token) != tokenLength {
+		t.Errorf("expected new token of %d characters, got %q", tokenLength, token)
+	}
+	if previous := string(secret.Data[rotationKey+PreviousTokenKeySuffix]); previous != "current" {
+		t.Errorf("expected previous token to be kept, got %q", previous)
+	}
+	rotatedAt := now.Truncate(time.Second)
+	if secret.Annotations[TokenRotatedAtAnnotation] != rotationTime(rotatedAt) ||
+		secret.Annotations[PreviousTokenExpiryAnnotation] != rotationTime(rotatedAt.Add(24*time.Hour)) {
+		t.Errorf("unexpected rotation annotations %v", secret.Annotations)
+	}
+	if !status.LastRotationTime.Time.Equal(rotatedAt) || !status.NextRotationTime.Time.Equal(rotatedAt.Add(720*time.Hour)) ||
+		status.PreviousTokenExpiry == nil || !status.PreviousTokenExpiry.Time.Equal(rotatedAt.Add(24*time.Hour)) {
+		t.Errorf("unexpected rotation status %+v", status)
+	}
+
+	// within the grace period the secret is not changed and the previous token is reported
+	changed, status, err = RotateSecretToken(secret, rotationKey, testTokenRotation, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || string(secret.Data[rotationKey]) != token || status.PreviousTokenExpiry == nil {
+		t.Errorf("expected secret not to be changed during grace period, got changed=%v status=%+v", changed, status)
+	}
+}
+
+func TestRotateSecretTokenRetiresPreviousToken(t *testing.T) {
+	rotatedAt := rotationCreated.Add(720 * time.Hour)
+	expiry := rotatedAt.Add(24 * time.Hour)
+	for name, tokenRotation := range map[string]*v1alpha1.IBMLicensingTokenRotation{
+		"rotation enabled": testTokenRotation,
+		// previous token is retired also when rotation was disabled during the grace period
+		"rotation disabled": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			secret := newTokenSecret(
+				map[string]string{
+					TokenRotatedAtAnnotation:      rotationTime(rotatedAt),
+					PreviousTokenExpiryAnnotation: rotationTime(expiry),
+				},
+				map[string][]byte{rotationKey: []byte("current"), rotationKey + PreviousTokenKeySuffix: []byte("previous")})
+			changed, status, err := RotateSecretToken(secret, rotationKey, tokenRotation, expiry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !changed {
+				t.Fatal("expected previous token to be removed")
+			}
+			if _, found := secret.Data[rotationKey+PreviousTokenKeySuffix]; found {
+				t.Error("expected previous token to be removed from the secret")
+			}
+			if _, found := secret.Annotations[PreviousTokenExpiryAnnotation]; found {
+				t.Error("expected previous token expiry annotation to be removed")
+			}
+			if string(secret.Data[rotationKey]) != "current" {
+				t.Errorf("expected current token to be kept, got %q", secret.Data[rotationKey])
+			}
+			if tokenRotation == nil {
+				if status != nil {
+					t.Errorf("expected no status when rotation is disabled, got %+v", status)
+				}
+			} else if status.PreviousTokenExpiry != nil {
+				t.Errorf("expected no previous token in status, got %+v", status)
+			}
+		})
+	}
+}
+
+func TestGetTokenRotationDelay(t *testing.T) {
+	now := rotationCreated
+	expiry := metav1.NewTime(now.Add(2 * time.Hour))
+	tests := []struct {
+		name     string
+		statuses []v1alpha1.TokenRotationStatus
+		expected time.Duration
+	}{
+		{
+			name:     "tokens are not rotated",
+			expected: 0,
+		},
+		{
+			name: "earliest next rotation",
+			statuses: []v1alpha1.TokenRotationStatus{
+				{NextRotationTime: metav1.NewTime(now.Add(5 * time.Hour))},
+				{NextRotationTime: metav1.NewTime(now.Add(3 * time.Hour))},
+			},
+			expected: 3 * time.Hour,
+		},
+		{
+			name: "previous token expiry before next rotation",
+			statuses: []v1alpha1.TokenRotationStatus{
+				{NextRotationTime: metav1.NewTime(now.Add(5 * time.Hour)), PreviousTokenExpiry: &expiry},
+				{NextRotationTime: metav1.NewTime(now.Add(3 * time.Hour))},
+			},
+			expected: 2 * time.Hour,
+		},
+		{
+			name: "overdue rotation is retried after a second",
+			statuses: []v1alpha1.TokenRotationStatus{
+				{NextRotationTime: metav1.NewTime(now.Add(-time.Hour))},
+			},
+			expected: time.Second,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if delay := GetTokenRotationDelay(test.statuses, now); delay != test.expected {
+				t.Errorf("expected delay %v, got %v", test.expected, delay)
+			}
+		})
+	}
+}
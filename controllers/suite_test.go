@@ -23,6 +23,7 @@ import (
 	"time"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	operatoribmcomv1 "github.com/ibm/ibm-licensing-operator/api/v1"
 	operatoribmcomv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -30,6 +31,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	meterdefv1beta1 "github.com/redhat-marketplace/redhat-marketplace-operator/v2/apis/marketplace/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -81,6 +83,9 @@ var _ = BeforeSuite(func(done Done) {
 	err = operatoribmcomv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = operatoribmcomv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = routev1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -96,6 +101,9 @@ var _ = BeforeSuite(func(done Done) {
 	err = meterdefv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = apiextensionsv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	// +kubebuilder:scaffold:scheme
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).ToNot(HaveOccurred())
@@ -107,22 +115,61 @@ var _ = BeforeSuite(func(done Done) {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	capabilityDetector, err := NewCapabilityDetector(cfg, ctrl.Log.WithName("capabilities"))
+	Expect(err).ToNot(HaveOccurred())
+	capabilityDetector.Client = mgr.GetClient()
+	Expect(capabilityDetector.Refresh()).To(Succeed())
+	Expect(capabilityDetector.SetupWithManager(mgr)).To(Succeed())
+
+	podExecutor, err := NewPodExecutor(cfg)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&IBMLicenseServiceReporterReconciler{
-		Client: mgr.GetClient(),
-		Reader: mgr.GetAPIReader(),
-		Log:    ctrl.Log.WithName("controllers").WithName("IBMLicenseServiceReporter"),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Reader:       mgr.GetAPIReader(),
+		Log:          ctrl.Log.WithName("controllers").WithName("IBMLicenseServiceReporter"),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		Capabilities: capabilityDetector,
+		Executor:     podExecutor,
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&IBMLicensingReconciler{
+		Client:       mgr.GetClient(),
+		Reader:       mgr.GetAPIReader(),
+		Log:          ctrl.Log.WithName("controllers").WithName("IBMLicensing"),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		Capabilities: capabilityDetector,
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&IBMLicensingMetadataReconciler{
 		Client: mgr.GetClient(),
-		Reader: mgr.GetAPIReader(),
-		Log:    ctrl.Log.WithName("controllers").WithName("IBMLicensing"),
+		Log:    ctrl.Log.WithName("controllers").WithName("IBMLicensingMetadata"),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&IBMLicensingAnnotationAuditReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("IBMLicensingAnnotationAudit"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("ibm-licensing-operator"),
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&IBMLicensingAuditSnapshotReconciler{
+		Client:       mgr.GetClient(),
+		Reader:       mgr.GetAPIReader(),
+		Log:          ctrl.Log.WithName("controllers").WithName("IBMLicensingAuditSnapshot"),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		Capabilities: capabilityDetector,
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
 	k8sClient = mgr.GetClient()
 	Expect(k8sClient).ToNot(BeNil())
 
@@ -9,11 +9,15 @@ require (
 	github.com/onsi/ginkgo v1.14.2
 	github.com/onsi/gomega v1.10.3
 	github.com/openshift/api v0.0.0-20200930075302-db52bc4ef99f
+	github.com/prometheus/client_golang v1.8.0
 	github.com/redhat-marketplace/redhat-marketplace-operator/v2 v2.0.0-20210125205956-4eda6b4abf4e
+	github.com/robfig/cron/v3 v3.0.1
 	k8s.io/api v0.19.4
+	k8s.io/apiextensions-apiserver v0.19.3
 	k8s.io/apimachinery v0.19.4
 	k8s.io/client-go v12.0.0+incompatible
 	sigs.k8s.io/controller-runtime v0.6.4
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace k8s.io/client-go => k8s.io/client-go v0.19.4
@@ -22,21 +22,27 @@ import (
 	"io/ioutil"
 	"os"
 	r "runtime"
+	"time"
 
 	"github.com/ibm/ibm-licensing-operator/version"
 	servicecav1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	meterdefv1beta1 "github.com/redhat-marketplace/redhat-marketplace-operator/v2/apis/marketplace/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	operatoribmcomv1 "github.com/ibm/ibm-licensing-operator/api/v1"
 	operatoribmcomv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
 	"github.com/ibm/ibm-licensing-operator/controllers"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
 	networkingv1 "k8s.io/api/networking/v1"
 
 	odlm "github.com/IBM/operand-deployment-lifecycle-manager/api/v1alpha1"
@@ -71,6 +77,10 @@ func init() {
 
 	utilruntime.Must(operatoribmcomv1alpha1.AddToScheme(scheme))
 
+	utilruntime.Must(operatoribmcomv1.AddToScheme(scheme))
+
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+
 	utilruntime.Must(routev1.AddToScheme(scheme))
 
 	utilruntime.Must(servicecav1.AddToScheme(scheme))
@@ -100,12 +110,57 @@ func getWatchNamespace() (string, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		if err := runRender(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == verifySnapshotCommand {
+		if err := runVerifySnapshot(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == snapshot.Command {
+		if err := runAuditSnapshot(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enablePodMetadataWebhook bool
+	var enableAnnotationAudit bool
+	var enableWebhooks bool
+	var reconcileOptions controllers.ReconcileOptions
+	var capabilityRefreshInterval time.Duration
+	var auditSnapshotImage string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable validating and defaulting webhooks for IBMLicensing and IBMLicenseServiceReporter, "+
+			"and conversion webhook for IBMLicensing.")
+	flag.BoolVar(&enablePodMetadataWebhook, "enable-pod-metadata-webhook", false,
+		"Enable pod mutating webhook, which applies IBMLicensingMetadata extend annotations to created pods.")
+	flag.BoolVar(&enableAnnotationAudit, "enable-annotation-audit", false,
+		"Enable IBMLicensingAnnotationAudit controller, which watches pods in all namespaces and audits their licensing annotations.")
+	flag.IntVar(&reconcileOptions.MaxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Maximum number of concurrent reconciliations of IBMLicensing and IBMLicenseServiceReporter.")
+	flag.DurationVar(&reconcileOptions.BackoffBase, "reconcile-backoff-base", controllers.DefaultBackoffBase,
+		"Delay before the first retry of failed reconciliation, doubled with every following failure.")
+	flag.DurationVar(&reconcileOptions.BackoffMax, "reconcile-backoff-max", controllers.DefaultBackoffMax,
+		"Maximum delay between retries of failed reconciliation.")
+	flag.DurationVar(&capabilityRefreshInterval, "capability-refresh-interval", controllers.DefaultCapabilityRefreshInterval,
+		"Interval of periodic discovery of optional APIs in the cluster, such as Route API or OpenShift service CA.")
+	flag.StringVar(&auditSnapshotImage, "audit-snapshot-image", "",
+		"Image of jobs storing scheduled audit snapshots, the image of the operator pod is used when empty.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -131,25 +186,126 @@ func main() {
 		os.Exit(1)
 	}
 
+	capabilityDetector, err := controllers.NewCapabilityDetector(mgr.GetConfig(), ctrl.Log.WithName("capabilities"))
+	if err != nil {
+		setupLog.Error(err, "unable to create capability detector")
+		os.Exit(1)
+	}
+	capabilityDetector.Client = mgr.GetClient()
+	capabilityDetector.Interval = capabilityRefreshInterval
+	// capabilities are discovered before controllers are set up, as they decide which resources are watched
+	if err = capabilityDetector.Refresh(); err != nil {
+		setupLog.Error(err, "unable to discover cluster capabilities")
+	}
+	if err = capabilityDetector.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CapabilityDetector")
+		os.Exit(1)
+	}
+	// IBMLicensingMetadata, pods and namespaces are watched in all namespaces also when WATCH_NAMESPACE is set
+	clusterCache, err := controllers.NewClusterCache(mgr, watchNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to create cluster cache")
+		os.Exit(1)
+	}
+	podExecutor, err := controllers.NewPodExecutor(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create pod executor")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.IBMLicensingReconciler{
 		Client:            mgr.GetClient(),
 		Reader:            mgr.GetAPIReader(),
 		Log:               ctrl.Log.WithName("controllers").WithName("IBMLicensing"),
 		Scheme:            mgr.GetScheme(),
+		Recorder:          mgr.GetEventRecorderFor("ibm-licensing-operator"),
 		OperatorNamespace: watchNamespace,
+		Options:           reconcileOptions,
+		Capabilities:      capabilityDetector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IBMLicensing")
 		os.Exit(1)
 	}
 	if err = (&controllers.IBMLicenseServiceReporterReconciler{
-		Client: mgr.GetClient(),
-		Reader: mgr.GetAPIReader(),
-		Log:    ctrl.Log.WithName("controllers").WithName("IBMLicenseServiceReporter"),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Reader:       mgr.GetAPIReader(),
+		Log:          ctrl.Log.WithName("controllers").WithName("IBMLicenseServiceReporter"),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		Options:      reconcileOptions,
+		Capabilities: capabilityDetector,
+		Executor:     podExecutor,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IBMLicenseServiceReporter")
 		os.Exit(1)
 	}
+	if err = (&controllers.IBMLicensingMetadataReconciler{
+		Client:       mgr.GetClient(),
+		ClusterCache: clusterCache,
+		Log:          ctrl.Log.WithName("controllers").WithName("IBMLicensingMetadata"),
+		Scheme:       mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IBMLicensingMetadata")
+		os.Exit(1)
+	}
+	if enableAnnotationAudit {
+		if err = (&controllers.IBMLicensingAnnotationAuditReconciler{
+			Client:       mgr.GetClient(),
+			ClusterCache: clusterCache,
+			Log:          ctrl.Log.WithName("controllers").WithName("IBMLicensingAnnotationAudit"),
+			Scheme:       mgr.GetScheme(),
+			Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IBMLicensingAnnotationAudit")
+			os.Exit(1)
+		}
+	}
+	if err = (&controllers.IBMLicensingAuditSnapshotReconciler{
+		Client:            mgr.GetClient(),
+		Reader:            mgr.GetAPIReader(),
+		Log:               ctrl.Log.WithName("controllers").WithName("IBMLicensingAuditSnapshot"),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		OperatorNamespace: watchNamespace,
+		Capabilities:      capabilityDetector,
+		Image:             auditSnapshotImage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IBMLicensingAuditSnapshot")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		mgr.GetWebhookServer().Register(controllers.IBMLicensingValidatingWebhookPath, &webhook.Admission{Handler: &controllers.IBMLicensingValidator{
+			Client:            mgr.GetClient(),
+			Log:               ctrl.Log.WithName("webhooks").WithName("IBMLicensing"),
+			OperatorNamespace: watchNamespace,
+			Capabilities:      capabilityDetector,
+		}})
+		mgr.GetWebhookServer().Register(controllers.IBMLicenseServiceReporterValidatingWebhookPath,
+			&webhook.Admission{Handler: &controllers.IBMLicenseServiceReporterValidator{
+				Client:       mgr.GetClient(),
+				Reader:       mgr.GetAPIReader(),
+				Log:          ctrl.Log.WithName("webhooks").WithName("IBMLicenseServiceReporter"),
+				Capabilities: capabilityDetector,
+			}})
+		mgr.GetWebhookServer().Register(controllers.IBMLicensingDefaultingWebhookPath, &webhook.Admission{Handler: &controllers.IBMLicensingDefaulter{
+			Log:               ctrl.Log.WithName("webhooks").WithName("IBMLicensing"),
+			OperatorNamespace: watchNamespace,
+			Capabilities:      capabilityDetector,
+		}})
+		mgr.GetWebhookServer().Register(controllers.IBMLicenseServiceReporterDefaultingWebhookPath,
+			&webhook.Admission{Handler: &controllers.IBMLicenseServiceReporterDefaulter{
+				Reader: mgr.GetAPIReader(),
+				Log:    ctrl.Log.WithName("webhooks").WithName("IBMLicenseServiceReporter"),
+			}})
+		mgr.GetWebhookServer().Register("/convert", &conversion.Webhook{})
+	}
+	if enablePodMetadataWebhook {
+		mgr.GetWebhookServer().Register(controllers.PodMetadataWebhookPath, &webhook.Admission{Handler: &controllers.PodMetadataMutator{
+			ClusterCache: clusterCache,
+			Log:          ctrl.Log.WithName("webhooks").WithName("PodMetadata"),
+			Recorder:     mgr.GetEventRecorderFor("ibm-licensing-operator"),
+		}})
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -0,0 +1,266 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	operatoribmcomv1 "github.com/ibm/ibm-licensing-operator/api/v1"
+	operatoribmcomv1alpha1 "github.com/ibm/ibm-licensing-operator/api/v1alpha1"
+	res "github.com/ibm/ibm-licensing-operator/controllers/resources"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/reporter"
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/service"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+const renderCommand = "render"
+
+const redactedValue = "<redacted>"
+
+// renderOptions stand in for cluster capabilities, which the operator discovers when it runs in the cluster
+type renderOptions struct {
+	filename          string
+	operatorNamespace string
+	storageClass      string
+	capabilities      res.Capabilities
+	rhmp              bool
+}
+
+// runRender prints manifests which the operator creates for IBMLicensing and IBMLicenseServiceReporter from given file,
+// without connecting to the cluster
+func runRender(args []string, out io.Writer) error {
+	options := renderOptions{}
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	flags.StringVar(&options.filename, "f", "-", "File with IBMLicensing or IBMLicenseServiceReporter, - reads from standard input.")
+	flags.StringVar(&options.operatorNamespace, "namespace", "ibm-common-services",
+		"Namespace of the operator, used when IBMLicensing does not set instanceNamespace.")
+	flags.StringVar(&options.storageClass, "storage-class", "default",
+		"Default storage class of the cluster, used when IBMLicenseServiceReporter does not set storageClass.")
+	flags.BoolVar(&options.capabilities.RouteAPI, "openshift", false, "Render for OpenShift cluster, with Route API available.")
+	flags.BoolVar(&options.capabilities.ServiceCAAPI, "service-ca", false, "Render for cluster with OpenShift service CA available.")
+	flags.BoolVar(&options.capabilities.ODLM, "odlm", false, "Render for cluster with Operand Deployment Lifecycle Manager installed.")
+	flags.BoolVar(&options.rhmp, "rhmp", false, "Render for cluster with Red Hat Marketplace enabled by default.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	input, err := readRenderInput(options.filename)
+	if err != nil {
+		return err
+	}
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(input), 4096)
+	for {
+		document := runtime.RawExtension{}
+		if err := decoder.Decode(&document); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(document.Raw)) == 0 {
+			continue
+		}
+		objects, err := renderDocument(document.Raw, options)
+		if err != nil {
+			return err
+		}
+		if err := printObjects(out, objects); err != nil {
+			return err
+		}
+	}
+}
+
+func readRenderInput(filename string) ([]byte, error) {
+	if filename == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(filename)
+}
+
+func renderDocument(document []byte, options renderOptions) ([]runtime.Object, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+		return nil, err
+	}
+	switch typeMeta.GroupVersionKind() {
+	case operatoribmcomv1alpha1.GroupVersion.WithKind("IBMLicensing"):
+		instance := &operatoribmcomv1alpha1.IBMLicensing{}
+		if err := yaml.UnmarshalStrict(document, instance); err != nil {
+			return nil, err
+		}
+		return renderIBMLicensing(instance, options)
+	case operatoribmcomv1.GroupVersion.WithKind("IBMLicensing"):
+		v1Instance := &operatoribmcomv1.IBMLicensing{}
+		if err := yaml.UnmarshalStrict(document, v1Instance); err != nil {
+			return nil, err
+		}
+		instance := &operatoribmcomv1alpha1.IBMLicensing{}
+		if err := v1Instance.ConvertTo(instance); err != nil {
+			return nil, err
+		}
+		return renderIBMLicensing(instance, options)
+	case operatoribmcomv1alpha1.GroupVersion.WithKind("IBMLicenseServiceReporter"):
+		instance := &operatoribmcomv1alpha1.IBMLicenseServiceReporter{}
+		if err := yaml.UnmarshalStrict(document, instance); err != nil {
+			return nil, err
+		}
+		return renderIBMLicenseServiceReporter(instance, options)
+	default:
+		return nil, fmt.Errorf("can not render %s %s, only IBMLicensing and IBMLicenseServiceReporter are supported",
+			typeMeta.APIVersion, typeMeta.Kind)
+	}
+}
+
+// renderIBMLicensing returns resources created by IBMLicensing controller, in the order of reconcile steps
+func renderIBMLicensing(instance *operatoribmcomv1alpha1.IBMLicensing, options renderOptions) ([]runtime.Object, error) {
+	capabilities := options.capabilities
+	err := instance.Spec.FillDefaultValues(capabilities.ServiceCAAPI, capabilities.RouteAPI, options.rhmp, options.operatorNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	apiSecret, err := service.GetAPISecretToken(instance)
+	if err != nil {
+		return nil, err
+	}
+	uploadSecret, err := service.GetUploadToken(instance)
+	if err != nil {
+		return nil, err
+	}
+	objects := []runtime.Object{
+		apiSecret,
+		uploadSecret,
+		service.GetUploadConfigMap(instance),
+		service.GetInfoConfigMap(instance),
+	}
+	services, _ := service.GetServices(instance, capabilities)
+	for _, expectedService := range services {
+		objects = append(objects, expectedService)
+	}
+	objects = append(objects, service.GetLicensingDeployment(instance, capabilities, nil))
+	if instance.Spec.IsIngressEnabled() {
+		objects = append(objects, service.GetLicensingIngress(instance))
+	}
+	if capabilities.RouteAPI && instance.Spec.IsRouteEnabled() {
+		objects = append(objects, service.GetLicensingRoute(instance))
+	}
+	if instance.Spec.IsRHMPEnabled() {
+		for _, meterDefinition := range service.GetMeterDefinition(instance) {
+			objects = append(objects, meterDefinition)
+		}
+		objects = append(objects, service.GetServiceMonitor(instance), service.GetNetworkPolicy(instance))
+	}
+	return objects, nil
+}
+
+// renderIBMLicenseServiceReporter returns resources created by IBMLicenseServiceReporter controller, in the order of reconcile steps
+func renderIBMLicenseServiceReporter(instance *operatoribmcomv1alpha1.IBMLicenseServiceReporter,
+	options renderOptions) ([]runtime.Object, error) {
+	if instance.GetNamespace() == "" {
+		instance.SetNamespace(options.operatorNamespace)
+	}
+	defaultStorageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        options.storageClass,
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		},
+	}
+	reader := fake.NewFakeClientWithScheme(scheme, defaultStorageClass)
+	if err := instance.Spec.FillDefaultValues(ctrl.Log.WithName("render"), reader); err != nil {
+		return nil, err
+	}
+
+	apiSecret, err := reporter.GetAPISecretToken(instance)
+	if err != nil {
+		return nil, err
+	}
+	databaseSecret, err := reporter.GetDatabaseSecret(instance)
+	if err != nil {
+		return nil, err
+	}
+	objects := []runtime.Object{
+		reporter.GetServiceAccount(instance),
+		reporter.GetRole(instance),
+		reporter.GetRoleBinding(instance),
+		apiSecret,
+		databaseSecret,
+		reporter.GetPersistenceVolumeClaim(instance),
+		reporter.GetService(instance, options.capabilities),
+		reporter.GetZenConfigMap(instance),
+	}
+	if options.capabilities.ODLM {
+		objects = append(objects, reporter.GetBindInfo(instance))
+	}
+	// platform OIDC credentials secret can not be checked without the cluster, so UI is rendered only when enabled explicitly
+	uiEnabled := instance.Spec.GetUIEnabledMode() == operatoribmcomv1alpha1.UIEnabledTrue
+	objects = append(objects, reporter.GetDeployment(instance, options.capabilities, uiEnabled, nil))
+	if options.capabilities.RouteAPI {
+		objects = append(objects, reporter.GetReporterRoute(instance))
+	}
+	objects = append(objects, reporter.GetUIIngress(instance), reporter.GetUIIngressProxy(instance))
+	return objects, nil
+}
+
+// printObjects writes objects as YAML documents, with secret values redacted
+func printObjects(out io.Writer, objects []runtime.Object) error {
+	for _, object := range objects {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		if err != nil {
+			return err
+		}
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+		if secret, ok := object.(*corev1.Secret); ok {
+			redactSecret(secret)
+		}
+		document, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func redactSecret(secret *corev1.Secret) {
+	if len(secret.Data) == 0 && len(secret.StringData) == 0 {
+		return
+	}
+	redacted := map[string]string{}
+	for key := range secret.Data {
+		redacted[key] = redactedValue
+	}
+	for key := range secret.StringData {
+		redacted[key] = redactedValue
+	}
+	secret.Data = nil
+	secret.StringData = redacted
+}
@@ -0,0 +1,71 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
+)
+
+const verifySnapshotCommand = "verify-snapshot"
+
+// runVerifySnapshot checks audit snapshot archive against its SHA-256 manifest and detached signature of the manifest
+func runVerifySnapshot(args []string, out io.Writer) error {
+	var archiveFile, manifestFile, signatureFile, publicKeyFile string
+	flags := flag.NewFlagSet(verifySnapshotCommand, flag.ContinueOnError)
+	flags.StringVar(&archiveFile, "archive", "", "Audit snapshot archive.")
+	flags.StringVar(&manifestFile, "manifest", "", "SHA-256 manifest of the archive, defaults to the archive name with "+
+		snapshot.ManifestSuffix+" suffix.")
+	flags.StringVar(&signatureFile, "signature", "", "Detached signature of the manifest, defaults to the archive name with "+
+		snapshot.SignatureSuffix+" suffix.")
+	flags.StringVar(&publicKeyFile, "public-key", "", "PEM encoded ed25519 or RSA public key, certificate or private key.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if archiveFile == "" || publicKeyFile == "" {
+		return errors.New("--archive and --public-key must be set")
+	}
+	if manifestFile == "" {
+		manifestFile = archiveFile + snapshot.ManifestSuffix
+	}
+	if signatureFile == "" {
+		signatureFile = archiveFile + snapshot.SignatureSuffix
+	}
+
+	files := map[string][]byte{}
+	for _, file := range []string{archiveFile, manifestFile, signatureFile, publicKeyFile} {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		files[file] = data
+	}
+	publicKey, err := snapshot.ParsePublicKey(files[publicKeyFile])
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Verify(publicKey, files[archiveFile], files[manifestFile], files[signatureFile]); err != nil {
+		return fmt.Errorf("verification of %s failed: %w", archiveFile, err)
+	}
+	fmt.Fprintf(out, "%s: verified\n", archiveFile)
+	return nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2021 IBM Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ibm/ibm-licensing-operator/controllers/resources/snapshot"
+)
+
+func TestRunVerifySnapshot(t *testing.T) {
+	dir := t.TempDir()
+	archiveFile := filepath.Join(dir, "snapshot.zip")
+	publicKeyFile := filepath.Join(dir, "public.pem")
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := []byte("audit snapshot archive")
+	manifest := snapshot.GetManifest(filepath.Base(archiveFile), archive)
+	signature, err := snapshot.Sign(privateKey, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, data := range map[string][]byte{
+		archiveFile:                            archive,
+		archiveFile + snapshot.ManifestSuffix:  manifest,
+		archiveFile + snapshot.SignatureSuffix: signature,
+		publicKeyFile:                          pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}),
+	} {
+		if err := ioutil.WriteFile(file, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	if err := runVerifySnapshot([]string{"--archive", archiveFile, "--public-key", publicKeyFile}, out); err != nil {
+		t.Fatalf("expected signed archive to be verified, got %v", err)
+	}
+	if !strings.Contains(out.String(), "verified") {
+		t.Errorf("expected verified output, got %q", out.String())
+	}
+
+	if err := ioutil.WriteFile(archiveFile, []byte("tampered audit snapshot archive"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	err = runVerifySnapshot([]string{"--archive", archiveFile, "--public-key", publicKeyFile}, ioutil.Discard)
+	if err == nil || !strings.Contains(err.Error(), "the archive was modified") {
+		t.Errorf("expected tampered archive to be detected, got %v", err)
+	}
+}